@@ -76,6 +76,162 @@ func assertExistingEntriesError(t *testing.T, got, want error) {
 	assertError(t, got, want)
 }
 
+func assertSealedError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned when a sealed entry is modified")
+	}
+	assertError(t, got, want)
+}
+
+func assertInvalidTreeError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned when an invalid tree is sealed")
+	}
+	assertError(t, got, want)
+}
+
+func assertNotFoundError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned when an entry is not found")
+	}
+	assertError(t, got, want)
+}
+
+func assertAmbiguousError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned when an entry is ambiguous")
+	}
+	assertError(t, got, want)
+}
+
+func assertUnknownCommandError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an unknown command")
+	}
+	assertError(t, got, want)
+}
+
+func assertUnknownOptionError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an unknown option")
+	}
+	assertError(t, got, want)
+}
+
+func assertParseError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with invalid args")
+	}
+	assertError(t, got, want)
+}
+
+func assertParsedOptions(t *testing.T, got, want []ParsedOption) {
+	if len(got) != len(want) {
+		t.Fatalf("%d parsed options returned but wanted %d", len(got), len(want))
+	}
+	for i, gotOption := range got {
+		if gotOption.Alias != want[i].Alias {
+			t.Errorf("parsed alias is %q but should be %q", gotOption.Alias, want[i].Alias)
+		}
+		assertValues(t, gotOption.Values, want[i].Values)
+	}
+}
+
+func assertValues(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d values returned but wanted %d", len(got), len(want))
+	}
+	for i, gotValue := range got {
+		if gotValue != want[i] {
+			t.Errorf("value is %q but should be %q", gotValue, want[i])
+		}
+	}
+}
+
+func assertConflictError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with a conflicting name or alias")
+	}
+	assertError(t, got, want)
+}
+
+func assertGroupError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an invalid option group")
+	}
+	assertError(t, got, want)
+}
+
+func assertCategoryError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an invalid category")
+	}
+	assertError(t, got, want)
+}
+
+func assertEnvError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an invalid env value")
+	}
+	assertError(t, got, want)
+}
+
+func assertArgsCheckError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with invalid positional args")
+	}
+	assertError(t, got, want)
+}
+
+func assertConstraintError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an invalid option constraint")
+	}
+	assertError(t, got, want)
+}
+
+func assertOptionsCheckError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with invalid options")
+	}
+	assertError(t, got, want)
+}
+
+func assertExitCodeError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an invalid exit code")
+	}
+	assertError(t, got, want)
+}
+
+func assertMatchingError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an unknown matching mode")
+	}
+	assertError(t, got, want)
+}
+
+func assertEmptyCommandStringError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an empty command string")
+	}
+	assertError(t, got, want)
+}
+
+func assertExampleError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with invalid examples")
+	}
+	assertErrorString(t, got.Error(), want.Error())
+}
+
+func assertSectionError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with an invalid section")
+	}
+	assertError(t, got, want)
+}
+
 func assertUninitializedError(t *testing.T, got, want error) {
 	if got == nil {
 		t.Fatal("no error returned with uninitialized global usage")
@@ -83,6 +239,13 @@ func assertUninitializedError(t *testing.T, got, want error) {
 	assertError(t, got, want)
 }
 
+func assertUninitializedProgramError(t *testing.T, got, want error) {
+	if got == nil {
+		t.Fatal("no error returned with uninitialized program")
+	}
+	assertError(t, got, want)
+}
+
 func assertUninitializedPanic(t *testing.T, want error) {
 	r := recover()
 	if r == nil {
@@ -154,6 +317,227 @@ func assertArgs(t *testing.T, got, want []string) {
 	}
 }
 
+func assertGroup(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("group is %q but should be %q", got, want)
+	}
+}
+
+func assertGroups(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d groups returned but wanted %d", len(got), len(want))
+	}
+	for i, gotGroup := range got {
+		if gotGroup != want[i] {
+			t.Errorf("group is %q but should be %q", gotGroup, want[i])
+		}
+	}
+}
+
+func assertCategory(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("category is %q but should be %q", got, want)
+	}
+}
+
+func assertCategories(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d categories returned but wanted %d", len(got), len(want))
+	}
+	for i, gotCategory := range got {
+		if gotCategory != want[i] {
+			t.Errorf("category is %q but should be %q", gotCategory, want[i])
+		}
+	}
+}
+
+func assertEntryNames(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d entries returned but wanted %d", len(got), len(want))
+	}
+	for i, gotName := range got {
+		if gotName != want[i] {
+			t.Errorf("entry is %q but should be %q", gotName, want[i])
+		}
+	}
+}
+
+func assertEnvVars(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d env vars returned but wanted %d", len(got), len(want))
+	}
+	for i, gotVar := range got {
+		if gotVar != want[i] {
+			t.Errorf("env var is %q but should be %q", gotVar, want[i])
+		}
+	}
+}
+
+func assertFlagValue(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("flag value is %q but should be %q", got, want)
+	}
+}
+
+func assertRequiredOptions(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d required options returned but wanted %d", len(got), len(want))
+	}
+	for i, gotAlias := range got {
+		if gotAlias != want[i] {
+			t.Errorf("required option is %q but should be %q", gotAlias, want[i])
+		}
+	}
+}
+
+func assertConstraintGroups(t *testing.T, got, want [][]string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d constraint groups returned but wanted %d", len(got), len(want))
+	}
+	for i, gotGroup := range got {
+		if strings.Join(gotGroup, " ") != strings.Join(want[i], " ") {
+			t.Errorf("constraint group is %q but should be %q", gotGroup, want[i])
+		}
+	}
+}
+
+func assertNotes(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d notes returned but wanted %d", len(got), len(want))
+	}
+	for i, gotNote := range got {
+		if gotNote != want[i] {
+			t.Errorf("note is %q but should be %q", gotNote, want[i])
+		}
+	}
+}
+
+func assertSummary(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("summary is %q but should be %q", got, want)
+	}
+}
+
+func assertDeprecation(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("deprecation is %q but should be %q", got, want)
+	}
+}
+
+func assertWarnings(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d warnings returned but wanted %d", len(got), len(want))
+	}
+	for i, gotWarning := range got {
+		if gotWarning != want[i] {
+			t.Errorf("warning is %q but should be %q", gotWarning, want[i])
+		}
+	}
+}
+
+func assertStability(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("stability is %q but should be %q", got, want)
+	}
+}
+
+func assertVersion(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("version is %q but should be %q", got, want)
+	}
+}
+
+func assertExitCode(t *testing.T, got, want int) {
+	if got != want {
+		t.Errorf("exit code is %d but should be %d", got, want)
+	}
+}
+
+func assertExitCodes(t *testing.T, got, want []ExitCode) {
+	if len(got) != len(want) {
+		t.Fatalf("%d exit codes returned but wanted %d", len(got), len(want))
+	}
+	for i, gotCode := range got {
+		if gotCode.Code != want[i].Code || gotCode.Meaning != want[i].Meaning {
+			t.Errorf("exit code is %d %q but should be %d %q", gotCode.Code, gotCode.Meaning, want[i].Code, want[i].Meaning)
+		}
+	}
+}
+
+func assertErrorOutput(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("error output is %q but should be %q", got, want)
+	}
+}
+
+func assertPath(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("path is %q but should be %q", got, want)
+	}
+}
+
+func assertMatching(t *testing.T, got, want Matching) {
+	if got != want {
+		t.Errorf("matching is %d but should be %d", got, want)
+	}
+}
+
+func assertSuggestions(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d suggestions returned but wanted %d", len(got), len(want))
+	}
+	for i, gotSuggestion := range got {
+		if gotSuggestion != want[i] {
+			t.Errorf("suggestion is %q but should be %q", gotSuggestion, want[i])
+		}
+	}
+}
+
+func assertExamples(t *testing.T, got, want []Example) {
+	if len(got) != len(want) {
+		t.Fatalf("%d examples returned but wanted %d", len(got), len(want))
+	}
+	for i, gotExample := range got {
+		if gotExample != want[i] {
+			t.Errorf("example is %+v but should be %+v", gotExample, want[i])
+		}
+	}
+}
+
+func assertLines(t *testing.T, got, want []string) {
+	if len(got) != len(want) {
+		t.Fatalf("%d lines returned but wanted %d", len(got), len(want))
+	}
+	for i, gotLine := range got {
+		if gotLine != want[i] {
+			t.Errorf("line is %q but should be %q", gotLine, want[i])
+		}
+	}
+}
+
+func assertSections(t *testing.T, got, want []Section) {
+	if len(got) != len(want) {
+		t.Fatalf("%d sections returned but wanted %d", len(got), len(want))
+	}
+	for i, gotSection := range got {
+		if gotSection != want[i] {
+			t.Errorf("section is %+v but should be %+v", gotSection, want[i])
+		}
+	}
+}
+
+func assertFooter(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("footer is %q but should be %q", got, want)
+	}
+}
+
+func assertWarningOutput(t *testing.T, got, want string) {
+	if got != want {
+		t.Errorf("warning output is %q but should be %q", got, want)
+	}
+}
+
 func assertAncestry(t *testing.T, got, want []string) {
 	if len(got) != len(want) {
 		t.Fatalf("%d ancestors returned but wanted %d", len(got), len(want))
@@ -234,6 +618,12 @@ func assertNilEntry(t *testing.T, got *Entry) {
 	}
 }
 
+func assertNilProgram(t *testing.T, got *Program) {
+	if got != nil {
+		t.Errorf("got %+v program but should be nil", got)
+	}
+}
+
 func assertEntries(t *testing.T, got, want []Entry) {
 	if len(got) != len(want) {
 		t.Fatalf("%d entries returned but wanted %d", len(got), len(want))
@@ -255,6 +645,55 @@ func assertErrorEquality(t *testing.T, got, want bool) {
 	}
 }
 
+func assertSealed(t *testing.T, got, want bool) {
+	if got != want {
+		t.Errorf("sealed is %t but should be %t", got, want)
+	}
+}
+
+func newPathTree() *Entry {
+	tmpl := template.Must(
+		template.New("").
+			Funcs(template.FuncMap{"join": strings.Join, "reverse": reverseAncestryChain}).
+			Parse(`{{join (reverse .Ancestry) ":"}}`),
+	)
+	root := &Entry{
+		name:     "base",
+		tmpl:     tmpl,
+		children: make(map[string]*Entry),
+		options: []Option{
+			{aliases: []string{"-v", "--verbose"}},
+			{aliases: []string{"--config"}, args: []string{"<file>"}},
+			{aliases: []string{"-o", "--output"}, args: []string{"<file>"}},
+		},
+	}
+	for _, path := range [][]string{{"db", "create"}, {"db", "drop"}, {"user", "create"}} {
+		ptr := root
+		for _, name := range path {
+			if ptr.children[name] == nil {
+				ptr.children[name] = &Entry{
+					name:     name,
+					tmpl:     tmpl,
+					children: make(map[string]*Entry),
+					parent:   ptr,
+				}
+			}
+			ptr = ptr.children[name]
+		}
+	}
+	root.children["db"].aliases = []string{"database"}
+	root.children["db"].children["drop"].aliases = []string{"rm"}
+	root.children["db"].options = []Option{{aliases: []string{"-f", "--force"}}}
+	root.children["db"].children["create"].args = []string{"<name>"}
+	return root
+}
+
+func newSealedTree() *tree {
+	sealedTree := new(tree)
+	sealedTree.sealed.Store(true)
+	return sealedTree
+}
+
 func stringToOption(str string) *Option {
 	const indent = "    "
 	aliasesAndArgsString, choppedDescription, _ := strings.Cut(str, "\n"+indent)
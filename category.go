@@ -0,0 +1,59 @@
+package usage
+
+import (
+	"errors"
+	"sort"
+)
+
+type Category struct {
+	Name    string
+	Entries []Entry
+}
+
+func (e *Entry) Category() string {
+	defer e.rlock()()
+	return e.category
+}
+
+func (e *Entry) SetCategory(category string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	e.category = category
+	return nil
+}
+
+func (e *Entry) SetCategoryOrder(categories ...string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	for _, category := range categories {
+		if category == "" {
+			return &UsageError{errors.New("category name must not be empty")}
+		}
+	}
+	e.categoryOrder = append([]string(nil), categories...)
+	return nil
+}
+
+func (e *Entry) Categories() []Category {
+	defer e.rlock()()
+	grouped := make(map[string][]Entry)
+	undeclared := make([]string, 0)
+	for _, entry := range e.entries() {
+		if _, ok := grouped[entry.category]; !ok && entry.category != "" && !contains(e.categoryOrder, entry.category) {
+			undeclared = append(undeclared, entry.category)
+		}
+		grouped[entry.category] = append(grouped[entry.category], entry)
+	}
+	sort.Strings(undeclared)
+	output := make([]Category, 0, len(grouped))
+	for _, name := range append(append([]string{""}, e.categoryOrder...), undeclared...) {
+		if entries, ok := grouped[name]; ok {
+			output = append(output, Category{Name: name, Entries: entries})
+		}
+	}
+	return output
+}
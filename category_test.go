@@ -0,0 +1,143 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entrySetCategoryTester struct {
+	iCategory string
+	oErr      error
+}
+
+func (tester entrySetCategoryTester) assertCategory() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "get"}
+		gotErr := sampleEntry.SetCategory(tester.iCategory)
+		assertNilError(t, gotErr)
+		assertCategory(t, sampleEntry.Category(), tester.iCategory)
+	}
+}
+
+func (tester entrySetCategoryTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "get", tree: newSealedTree()}
+		got := sampleEntry.SetCategory(tester.iCategory)
+		assertSealedError(t, got, tester.oErr)
+		assertCategory(t, sampleEntry.Category(), "")
+	}
+}
+
+type entrySetCategoryOrderTester struct {
+	iOrder []string
+	oErr   error
+}
+
+func (tester entrySetCategoryOrderTester) assertOrder() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "kubectl"}
+		gotErr := sampleEntry.SetCategoryOrder(tester.iOrder...)
+		assertNilError(t, gotErr)
+		assertCategories(t, sampleEntry.categoryOrder, tester.iOrder)
+	}
+}
+
+func (tester entrySetCategoryOrderTester) assertOrderError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "kubectl"}
+		got := sampleEntry.SetCategoryOrder(tester.iOrder...)
+		assertCategoryError(t, got, tester.oErr)
+		assertCategories(t, sampleEntry.categoryOrder, []string{})
+	}
+}
+
+func (tester entrySetCategoryOrderTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "kubectl", tree: newSealedTree()}
+		got := sampleEntry.SetCategoryOrder(tester.iOrder...)
+		assertSealedError(t, got, tester.oErr)
+		assertCategories(t, sampleEntry.categoryOrder, []string{})
+	}
+}
+
+type entryCategoriesTester struct {
+	iOrder      []string
+	oCategories []string
+	oEntries    [][]string
+}
+
+func (tester entryCategoriesTester) assertCategories() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name:          "kubectl",
+			categoryOrder: tester.iOrder,
+			children: map[string]*Entry{
+				"get":        {name: "get", category: "Basic Commands"},
+				"logs":       {name: "logs", category: "Troubleshooting"},
+				"create":     {name: "create", category: "Basic Commands"},
+				"completion": {name: "completion"},
+				"cordon":     {name: "cordon", category: "Management"},
+			},
+		}
+		got := sampleEntry.Categories()
+		names := make([]string, 0, len(got))
+		for _, category := range got {
+			names = append(names, category.Name)
+		}
+		assertCategories(t, names, tester.oCategories)
+		for i, category := range got {
+			entries := make([]string, 0)
+			for _, entry := range category.Entries {
+				entries = append(entries, entry.name)
+			}
+			assertEntryNames(t, entries, tester.oEntries[i])
+		}
+	}
+}
+
+func TestEntrySetCategory(t *testing.T) {
+	t.Run("baseline", entrySetCategoryTester{
+		iCategory: "Basic Commands",
+	}.assertCategory())
+	t.Run("no category", entrySetCategoryTester{}.assertCategory())
+	t.Run("sealed", entrySetCategoryTester{
+		iCategory: "Basic Commands",
+		oErr:      errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntrySetCategoryOrder(t *testing.T) {
+	t.Run("baseline", entrySetCategoryOrderTester{
+		iOrder: []string{"Basic Commands", "Management"},
+	}.assertOrder())
+	t.Run("empty category name", entrySetCategoryOrderTester{
+		iOrder: []string{"Basic Commands", ""},
+		oErr:   errors.New("usage: category name must not be empty"),
+	}.assertOrderError())
+	t.Run("sealed", entrySetCategoryOrderTester{
+		iOrder: []string{"Basic Commands"},
+		oErr:   errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryCategories(t *testing.T) {
+	t.Run("baseline", entryCategoriesTester{
+		iOrder:      []string{"Basic Commands", "Management", "Troubleshooting"},
+		oCategories: []string{"", "Basic Commands", "Management", "Troubleshooting"},
+		oEntries:    [][]string{{"completion"}, {"create", "get"}, {"cordon"}, {"logs"}},
+	}.assertCategories())
+	t.Run("undeclared categories", entryCategoriesTester{
+		oCategories: []string{"", "Basic Commands", "Management", "Troubleshooting"},
+		oEntries:    [][]string{{"completion"}, {"create", "get"}, {"cordon"}, {"logs"}},
+	}.assertCategories())
+	t.Run("partially declared categories", entryCategoriesTester{
+		iOrder:      []string{"Troubleshooting"},
+		oCategories: []string{"", "Troubleshooting", "Basic Commands", "Management"},
+		oEntries:    [][]string{{"completion"}, {"logs"}, {"create", "get"}, {"cordon"}},
+	}.assertCategories())
+	t.Run("empty declared category", entryCategoriesTester{
+		iOrder:      []string{"Deprecated", "Management"},
+		oCategories: []string{"", "Management", "Basic Commands", "Troubleshooting"},
+		oEntries:    [][]string{{"completion"}, {"cordon"}, {"create", "get"}, {"logs"}},
+	}.assertCategories())
+}
@@ -0,0 +1,87 @@
+package usage
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (e *Entry) CheckArgs(positional []string) error {
+	s := e.snapshot(false)
+	required, variadic := make([]string, 0, len(s.args)), false
+	for _, arg := range s.args {
+		if !isOptionalArg(arg) {
+			required = append(required, arg)
+		}
+		variadic = variadic || isVariadicArg(arg)
+	}
+	if len(positional) < len(required) {
+		return &UsageError{fmt.Errorf("missing argument %s for '%s'", required[len(positional)], deriveSummaryString(*s))}
+	}
+	if !variadic && len(positional) > len(s.args) {
+		return &UsageError{fmt.Errorf("unexpected argument '%s' for '%s'", positional[len(s.args)], deriveSummaryString(*s))}
+	}
+	return nil
+}
+
+func isOptionalArg(arg string) bool {
+	return strings.HasPrefix(arg, "[")
+}
+
+func isVariadicArg(arg string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(arg, "]"), "...")
+}
+
+func (e *Entry) CheckOptions(passed []string) error {
+	defer e.rlock()()
+	used := make(map[string]bool)
+	for _, name := range passed {
+		if option := e.passedOption(name); option != nil {
+			used[option.aliases[0]] = true
+		}
+	}
+	isUsed := func(alias string) bool {
+		option := e.option(alias)
+		return option != nil && used[option.aliases[0]]
+	}
+	for _, alias := range e.required {
+		if !isUsed(alias) {
+			return &UsageError{fmt.Errorf("missing required option '%s' for '%s'", alias, e.path())}
+		}
+	}
+	for _, group := range e.exclusive {
+		var first string
+		for _, alias := range group {
+			if !isUsed(alias) {
+				continue
+			}
+			if first != "" {
+				return &UsageError{fmt.Errorf("options '%s' and '%s' cannot be used together for '%s'", first, alias, e.path())}
+			}
+			first = alias
+		}
+	}
+	for _, group := range e.together {
+		var present, missing string
+		for _, alias := range group {
+			if isUsed(alias) && present == "" {
+				present = alias
+			}
+			if !isUsed(alias) && missing == "" {
+				missing = alias
+			}
+		}
+		if present != "" && missing != "" {
+			return &UsageError{fmt.Errorf("option '%s' requires '%s' for '%s'", present, missing, e.path())}
+		}
+	}
+	return nil
+}
+
+func (e *Entry) passedOption(name string) *Option {
+	if option := e.option(name); option != nil {
+		return option
+	}
+	return e.findOption(func(alias string) bool {
+		return strings.TrimLeft(alias, "-") == name
+	})
+}
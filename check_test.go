@@ -0,0 +1,182 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entryCheckArgsTester struct {
+	iArgs       []string
+	iPositional []string
+	oErr        error
+}
+
+func (tester entryCheckArgsTester) newEntry() *Entry {
+	sampleEntry, _ := NewEntry("cp", "")
+	for _, arg := range tester.iArgs {
+		sampleEntry.AddArg(arg)
+	}
+	return sampleEntry
+}
+
+func (tester entryCheckArgsTester) assertNilError() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.newEntry().CheckArgs(tester.iPositional)
+		assertNilError(t, got)
+	}
+}
+
+func (tester entryCheckArgsTester) assertArgsError() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.newEntry().CheckArgs(tester.iPositional)
+		assertArgsCheckError(t, got, tester.oErr)
+	}
+}
+
+func TestEntryCheckArgs(t *testing.T) {
+	t.Run("baseline", entryCheckArgsTester{
+		iArgs:       []string{"<src>", "<dst>"},
+		iPositional: []string{"foo", "bar"},
+	}.assertNilError())
+	t.Run("no args", entryCheckArgsTester{
+		iPositional: []string{},
+	}.assertNilError())
+	t.Run("missing arg", entryCheckArgsTester{
+		iArgs:       []string{"<src>", "<dst>"},
+		iPositional: []string{"foo"},
+		oErr:        errors.New("usage: missing argument <dst> for 'cp <src> <dst>'"),
+	}.assertArgsError())
+	t.Run("missing all args", entryCheckArgsTester{
+		iArgs: []string{"<src>", "<dst>"},
+		oErr:  errors.New("usage: missing argument <src> for 'cp <src> <dst>'"),
+	}.assertArgsError())
+	t.Run("unexpected arg", entryCheckArgsTester{
+		iArgs:       []string{"<src>", "<dst>"},
+		iPositional: []string{"foo", "bar", "baz"},
+		oErr:        errors.New("usage: unexpected argument 'baz' for 'cp <src> <dst>'"),
+	}.assertArgsError())
+	t.Run("unexpected arg without declared args", entryCheckArgsTester{
+		iPositional: []string{"foo"},
+		oErr:        errors.New("usage: unexpected argument 'foo' for 'cp'"),
+	}.assertArgsError())
+	t.Run("optional arg given", entryCheckArgsTester{
+		iArgs:       []string{"<src>", "[dst]"},
+		iPositional: []string{"foo", "bar"},
+	}.assertNilError())
+	t.Run("optional arg omitted", entryCheckArgsTester{
+		iArgs:       []string{"<src>", "[dst]"},
+		iPositional: []string{"foo"},
+	}.assertNilError())
+	t.Run("missing arg before optional arg", entryCheckArgsTester{
+		iArgs:       []string{"[flags]", "<src>"},
+		iPositional: []string{},
+		oErr:        errors.New("usage: missing argument <src> for 'cp [flags] <src>'"),
+	}.assertArgsError())
+	t.Run("variadic arg", entryCheckArgsTester{
+		iArgs:       []string{"<src>...", "<dst>"},
+		iPositional: []string{"foo", "bar", "baz"},
+	}.assertNilError())
+	t.Run("missing variadic arg", entryCheckArgsTester{
+		iArgs: []string{"<src>..."},
+		oErr:  errors.New("usage: missing argument <src>... for 'cp <src>...'"),
+	}.assertArgsError())
+	t.Run("optional variadic arg", entryCheckArgsTester{
+		iArgs:       []string{"[src...]"},
+		iPositional: []string{"foo", "bar"},
+	}.assertNilError())
+	t.Run("optional variadic arg omitted", entryCheckArgsTester{
+		iArgs: []string{"[src]..."},
+	}.assertNilError())
+}
+
+type entryCheckOptionsTester struct {
+	iPassed []string
+	oErr    error
+}
+
+func (tester entryCheckOptionsTester) newEntry() *Entry {
+	sampleEntry := &Entry{
+		name: "deploy",
+		options: []Option{
+			{aliases: []string{"-t", "--token"}, args: []string{"<file>"}},
+			{aliases: []string{"--json"}},
+			{aliases: []string{"--yaml"}},
+			{aliases: []string{"--cert"}, args: []string{"<file>"}},
+			{aliases: []string{"--key"}},
+		},
+	}
+	sampleEntry.RequireOption("--token")
+	sampleEntry.AddExclusiveGroup("--json", "--yaml")
+	sampleEntry.AddCoRequiredGroup("--cert", "--key")
+	return sampleEntry
+}
+
+func (tester entryCheckOptionsTester) assertNilError() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.newEntry().CheckOptions(tester.iPassed)
+		assertNilError(t, got)
+	}
+}
+
+func (tester entryCheckOptionsTester) assertOptionsError() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.newEntry().CheckOptions(tester.iPassed)
+		assertOptionsCheckError(t, got, tester.oErr)
+	}
+}
+
+func (tester entryCheckOptionsTester) assertPersistentOption() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry, _ := NewEntry("base", "")
+		config, _ := NewOption([]string{"-c", "--config"}, "")
+		config.AddArg("<file>")
+		config.SetPersistent(true)
+		sampleEntry.AddOption(config)
+		deploy, _ := NewEntry("deploy", "")
+		sampleEntry.AddEntry(deploy)
+		assertNilError(t, deploy.RequireOption("--config"))
+		got := deploy.CheckOptions(tester.iPassed)
+		if tester.oErr == nil {
+			assertNilError(t, got)
+			return
+		}
+		assertError(t, got, tester.oErr)
+	}
+}
+
+func TestEntryCheckOptions(t *testing.T) {
+	t.Run("baseline", entryCheckOptionsTester{
+		iPassed: []string{"--token", "--json", "--cert", "--key"},
+	}.assertNilError())
+	t.Run("flag names", entryCheckOptionsTester{
+		iPassed: []string{"token", "yaml"},
+	}.assertNilError())
+	t.Run("other alias", entryCheckOptionsTester{
+		iPassed: []string{"-t"},
+	}.assertNilError())
+	t.Run("persistent option", entryCheckOptionsTester{
+		iPassed: []string{"--config"},
+	}.assertPersistentOption())
+	t.Run("persistent flag name", entryCheckOptionsTester{
+		iPassed: []string{"config"},
+	}.assertPersistentOption())
+	t.Run("missing persistent option", entryCheckOptionsTester{
+		iPassed: []string{"deploy"},
+		oErr:    errors.New("usage: missing required option '--config' for 'base deploy'"),
+	}.assertPersistentOption())
+	t.Run("untracked option", entryCheckOptionsTester{
+		iPassed: []string{"--token", "foo"},
+	}.assertNilError())
+	t.Run("missing required option", entryCheckOptionsTester{
+		iPassed: []string{"json"},
+		oErr:    errors.New("usage: missing required option '--token' for 'deploy'"),
+	}.assertOptionsError())
+	t.Run("exclusive options", entryCheckOptionsTester{
+		iPassed: []string{"token", "yaml", "json"},
+		oErr:    errors.New("usage: options '--json' and '--yaml' cannot be used together for 'deploy'"),
+	}.assertOptionsError())
+	t.Run("co-required options", entryCheckOptionsTester{
+		iPassed: []string{"--token", "--key"},
+		oErr:    errors.New("usage: option '--key' requires '--cert' for 'deploy'"),
+	}.assertOptionsError())
+}
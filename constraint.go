@@ -0,0 +1,136 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (e *Entry) RequireOption(alias string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if err := e.checkOptionAliases([]string{alias}); err != nil {
+		return err
+	}
+	e.required = append(e.required, alias)
+	return nil
+}
+
+func (e *Entry) AddExclusiveGroup(aliases ...string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if len(aliases) < 2 {
+		return &UsageError{errors.New("option group must have at least two options")}
+	}
+	if err := e.checkOptionAliases(aliases); err != nil {
+		return err
+	}
+	e.exclusive = append(e.exclusive, append([]string(nil), aliases...))
+	return nil
+}
+
+func (e *Entry) AddCoRequiredGroup(aliases ...string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if len(aliases) < 2 {
+		return &UsageError{errors.New("option group must have at least two options")}
+	}
+	if err := e.checkOptionAliases(aliases); err != nil {
+		return err
+	}
+	e.together = append(e.together, append([]string(nil), aliases...))
+	return nil
+}
+
+func (e *Entry) RequiredOptions() []string {
+	defer e.rlock()()
+	return append(make([]string, 0, len(e.required)), e.required...)
+}
+
+func (e *Entry) ExclusiveGroups() [][]string {
+	defer e.rlock()()
+	return append(make([][]string, 0, len(e.exclusive)), e.exclusive...)
+}
+
+func (e *Entry) CoRequiredGroups() [][]string {
+	defer e.rlock()()
+	return append(make([][]string, 0, len(e.together)), e.together...)
+}
+
+func (e *Entry) checkOptionAliases(aliases []string) error {
+	for _, alias := range aliases {
+		if alias == "" {
+			return &UsageError{errors.New("alias string must not be empty")}
+		}
+		if e.option(alias) == nil {
+			return &UsageError{fmt.Errorf("option '%s' not found in '%s'", alias, e.path())}
+		}
+	}
+	return nil
+}
+
+func (e *Entry) optionNotes(option Option) []string {
+	notes := make([]string, 0)
+	for _, alias := range e.required {
+		if option.hasAlias(alias) {
+			notes = append(notes, "required")
+		}
+	}
+	for _, group := range e.exclusive {
+		if others := otherAliases(option, group); len(others) < len(group) {
+			notes = append(notes, "excludes "+strings.Join(others, ", "))
+		}
+	}
+	for _, group := range e.together {
+		if others := otherAliases(option, group); len(others) < len(group) {
+			notes = append(notes, "requires "+strings.Join(others, ", "))
+		}
+	}
+	return notes
+}
+
+func (e *Entry) constraintSummary() string {
+	var b strings.Builder
+	for _, alias := range e.required {
+		b.WriteString(" " + e.optionSynopsis(alias))
+	}
+	for _, group := range e.exclusive {
+		parts := make([]string, 0, len(group))
+		for _, alias := range group {
+			parts = append(parts, e.optionSynopsis(alias))
+		}
+		b.WriteString(" (" + strings.Join(parts, " | ") + ")")
+	}
+	for _, group := range e.together {
+		parts := make([]string, 0, len(group))
+		for _, alias := range group {
+			parts = append(parts, e.optionSynopsis(alias))
+		}
+		b.WriteString(" [" + strings.Join(parts, " ") + "]")
+	}
+	return b.String()
+}
+
+func (e *Entry) optionSynopsis(alias string) string {
+	option := e.option(alias)
+	if option == nil || len(option.args) == 0 {
+		return alias
+	}
+	return alias + " " + strings.Join(option.args, " ")
+}
+
+func otherAliases(option Option, group []string) []string {
+	others := make([]string, 0, len(group))
+	for _, alias := range group {
+		if !option.hasAlias(alias) {
+			others = append(others, alias)
+		}
+	}
+	return others
+}
@@ -0,0 +1,256 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entryRequireOptionTester struct {
+	iAlias    string
+	oRequired []string
+	oErr      error
+}
+
+func (tester entryRequireOptionTester) assertRequired() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name: "deploy",
+			options: []Option{
+				{aliases: []string{"-t", "--token"}, args: []string{"<file>"}},
+				{aliases: []string{"--json"}},
+				{aliases: []string{"--yaml"}},
+				{aliases: []string{"--cert"}, args: []string{"<file>"}},
+				{aliases: []string{"--key"}},
+			},
+		}
+		gotErr := sampleEntry.RequireOption(tester.iAlias)
+		assertNilError(t, gotErr)
+		assertRequiredOptions(t, sampleEntry.RequiredOptions(), tester.oRequired)
+	}
+}
+
+func (tester entryRequireOptionTester) assertNotFoundError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name: "deploy",
+			options: []Option{
+				{aliases: []string{"-t", "--token"}, args: []string{"<file>"}},
+				{aliases: []string{"--json"}},
+				{aliases: []string{"--yaml"}},
+				{aliases: []string{"--cert"}, args: []string{"<file>"}},
+				{aliases: []string{"--key"}},
+			},
+		}
+		got := sampleEntry.RequireOption(tester.iAlias)
+		assertNotFoundError(t, got, tester.oErr)
+		assertRequiredOptions(t, sampleEntry.RequiredOptions(), []string{})
+	}
+}
+
+func (tester entryRequireOptionTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "deploy", tree: newSealedTree()}
+		got := sampleEntry.RequireOption(tester.iAlias)
+		assertSealedError(t, got, tester.oErr)
+	}
+}
+
+type entryAddGroupTester struct {
+	iAliases   []string
+	iExclusive bool
+	oGroups    [][]string
+	oErr       error
+}
+
+func (tester entryAddGroupTester) addGroup(e *Entry) error {
+	if tester.iExclusive {
+		return e.AddExclusiveGroup(tester.iAliases...)
+	}
+	return e.AddCoRequiredGroup(tester.iAliases...)
+}
+
+func (tester entryAddGroupTester) groups(e *Entry) [][]string {
+	if tester.iExclusive {
+		return e.ExclusiveGroups()
+	}
+	return e.CoRequiredGroups()
+}
+
+func (tester entryAddGroupTester) assertGroups() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name: "deploy",
+			options: []Option{
+				{aliases: []string{"-t", "--token"}, args: []string{"<file>"}},
+				{aliases: []string{"--json"}},
+				{aliases: []string{"--yaml"}},
+				{aliases: []string{"--cert"}, args: []string{"<file>"}},
+				{aliases: []string{"--key"}},
+			},
+		}
+		gotErr := tester.addGroup(sampleEntry)
+		assertNilError(t, gotErr)
+		assertConstraintGroups(t, tester.groups(sampleEntry), tester.oGroups)
+	}
+}
+
+func (tester entryAddGroupTester) assertGroupError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name: "deploy",
+			options: []Option{
+				{aliases: []string{"-t", "--token"}, args: []string{"<file>"}},
+				{aliases: []string{"--json"}},
+				{aliases: []string{"--yaml"}},
+				{aliases: []string{"--cert"}, args: []string{"<file>"}},
+				{aliases: []string{"--key"}},
+			},
+		}
+		got := tester.addGroup(sampleEntry)
+		assertConstraintError(t, got, tester.oErr)
+		assertConstraintGroups(t, tester.groups(sampleEntry), [][]string{})
+	}
+}
+
+func (tester entryAddGroupTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "deploy", tree: newSealedTree()}
+		got := tester.addGroup(sampleEntry)
+		assertSealedError(t, got, tester.oErr)
+	}
+}
+
+type entryConstraintUsageTester struct {
+	iRequired  []string
+	iExclusive [][]string
+	iTogether  [][]string
+	oSummary   string
+	oNotes     [][]string
+}
+
+func (tester entryConstraintUsageTester) newEntry() *Entry {
+	sampleEntry := &Entry{
+		name: "deploy",
+		options: []Option{
+			{aliases: []string{"-t", "--token"}, args: []string{"<file>"}},
+			{aliases: []string{"--json"}},
+			{aliases: []string{"--yaml"}},
+			{aliases: []string{"--cert"}, args: []string{"<file>"}},
+			{aliases: []string{"--key"}},
+		},
+	}
+	for _, alias := range tester.iRequired {
+		sampleEntry.RequireOption(alias)
+	}
+	for _, group := range tester.iExclusive {
+		sampleEntry.AddExclusiveGroup(group...)
+	}
+	for _, group := range tester.iTogether {
+		sampleEntry.AddCoRequiredGroup(group...)
+	}
+	return sampleEntry
+}
+
+func (tester entryConstraintUsageTester) assertSummary() func(*testing.T) {
+	return func(t *testing.T) {
+		got := deriveSummaryString(*tester.newEntry())
+		assertSummary(t, got, tester.oSummary)
+	}
+}
+
+func (tester entryConstraintUsageTester) assertNotes() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.newEntry().snapshot(false).options
+		for i, option := range got {
+			assertNotes(t, option.Notes(), tester.oNotes[i])
+		}
+	}
+}
+
+func TestEntryRequireOption(t *testing.T) {
+	t.Run("baseline", entryRequireOptionTester{
+		iAlias:    "--token",
+		oRequired: []string{"--token"},
+	}.assertRequired())
+	t.Run("short alias", entryRequireOptionTester{
+		iAlias:    "-t",
+		oRequired: []string{"-t"},
+	}.assertRequired())
+	t.Run("untracked option", entryRequireOptionTester{
+		iAlias: "--foo",
+		oErr:   errors.New("usage: option '--foo' not found in 'deploy'"),
+	}.assertNotFoundError())
+	t.Run("sealed", entryRequireOptionTester{
+		iAlias: "--token",
+		oErr:   errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryAddExclusiveGroup(t *testing.T) {
+	t.Run("baseline", entryAddGroupTester{
+		iAliases:   []string{"--json", "--yaml"},
+		iExclusive: true,
+		oGroups:    [][]string{{"--json", "--yaml"}},
+	}.assertGroups())
+	t.Run("single option", entryAddGroupTester{
+		iAliases:   []string{"--json"},
+		iExclusive: true,
+		oErr:       errors.New("usage: option group must have at least two options"),
+	}.assertGroupError())
+	t.Run("untracked option", entryAddGroupTester{
+		iAliases:   []string{"--json", "--toml"},
+		iExclusive: true,
+		oErr:       errors.New("usage: option '--toml' not found in 'deploy'"),
+	}.assertGroupError())
+	t.Run("sealed", entryAddGroupTester{
+		iAliases:   []string{"--json", "--yaml"},
+		iExclusive: true,
+		oErr:       errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryAddCoRequiredGroup(t *testing.T) {
+	t.Run("baseline", entryAddGroupTester{
+		iAliases: []string{"--cert", "--key"},
+		oGroups:  [][]string{{"--cert", "--key"}},
+	}.assertGroups())
+	t.Run("single option", entryAddGroupTester{
+		iAliases: []string{"--cert"},
+		oErr:     errors.New("usage: option group must have at least two options"),
+	}.assertGroupError())
+	t.Run("empty alias", entryAddGroupTester{
+		iAliases: []string{"--cert", ""},
+		oErr:     errors.New("usage: alias string must not be empty"),
+	}.assertGroupError())
+	t.Run("sealed", entryAddGroupTester{
+		iAliases: []string{"--cert", "--key"},
+		oErr:     errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryConstraintUsage(t *testing.T) {
+	t.Run("no constraints", entryConstraintUsageTester{
+		oSummary: "deploy [options]",
+	}.assertSummary())
+	t.Run("baseline", entryConstraintUsageTester{
+		iRequired:  []string{"--token"},
+		iExclusive: [][]string{{"--json", "--yaml"}},
+		iTogether:  [][]string{{"--cert", "--key"}},
+		oSummary:   "deploy [options] --token <file> (--json | --yaml) [--cert <file> --key]",
+	}.assertSummary())
+	t.Run("notes", entryConstraintUsageTester{
+		iRequired:  []string{"--token"},
+		iExclusive: [][]string{{"--json", "--yaml"}},
+		iTogether:  [][]string{{"--cert", "--key"}},
+		oNotes: [][]string{
+			{"required"},
+			{"excludes --yaml"},
+			{"excludes --json"},
+			{"requires --key"},
+			{"requires --cert"},
+		},
+	}.assertNotes())
+	t.Run("no notes", entryConstraintUsageTester{
+		oNotes: [][]string{{}, {}, {}, {}, {}},
+	}.assertNotes())
+}
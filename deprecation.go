@@ -0,0 +1,164 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Deprecation struct {
+	Message        string
+	Replacement    string
+	RemovalVersion string
+}
+
+func (d Deprecation) String() string {
+	details := make([]string, 0, 3)
+	if d.Replacement != "" {
+		details = append(details, "use "+d.Replacement)
+	}
+	if d.RemovalVersion != "" {
+		details = append(details, "will be removed in "+d.RemovalVersion)
+	}
+	if d.Message != "" {
+		details = append(details, d.Message)
+	}
+	if len(details) == 0 {
+		return "deprecated"
+	}
+	return "deprecated: " + strings.Join(details, "; ")
+}
+
+type aliasDeprecation struct {
+	alias       string
+	deprecation Deprecation
+}
+
+func (o Option) Deprecated() *Deprecation {
+	return o.deprecation
+}
+
+func (o *Option) SetDeprecated(deprecation *Deprecation) {
+	if deprecation == nil {
+		o.deprecation = nil
+		return
+	}
+	d := *deprecation
+	o.deprecation = &d
+}
+
+func (o Option) AliasDeprecated(alias string) *Deprecation {
+	for _, a := range o.aliasDeprecations {
+		if a.alias == alias {
+			d := a.deprecation
+			return &d
+		}
+	}
+	return nil
+}
+
+func (o *Option) DeprecateAlias(alias string, deprecation Deprecation) error {
+	if !o.hasAlias(alias) {
+		return &UsageError{fmt.Errorf("alias '%s' not found in option '%s'", alias, strings.Join(o.aliases, ", "))}
+	}
+	deprecations := make([]aliasDeprecation, 0, len(o.aliasDeprecations)+1)
+	for _, a := range o.aliasDeprecations {
+		if a.alias != alias {
+			deprecations = append(deprecations, a)
+		}
+	}
+	o.aliasDeprecations = append(deprecations, aliasDeprecation{alias, deprecation})
+	return nil
+}
+
+func (o Option) deprecationNotes() []string {
+	notes := make([]string, 0)
+	if o.deprecation != nil {
+		notes = append(notes, o.deprecation.String())
+	}
+	for _, alias := range o.aliases {
+		if d := o.AliasDeprecated(alias); d != nil {
+			notes = append(notes, alias+" "+d.String())
+		}
+	}
+	return notes
+}
+
+func (o Option) warning(alias string) string {
+	if d := o.AliasDeprecated(alias); d != nil {
+		return fmt.Sprintf("option '%s' is %s", alias, d)
+	}
+	if o.deprecation != nil {
+		return fmt.Sprintf("option '%s' is %s", alias, o.deprecation)
+	}
+	return ""
+}
+
+func (e *Entry) Deprecated() *Deprecation {
+	defer e.rlock()()
+	if e.deprecation == nil {
+		return nil
+	}
+	d := *e.deprecation
+	return &d
+}
+
+func (e *Entry) SetDeprecated(deprecation *Deprecation) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if deprecation == nil {
+		e.deprecation = nil
+		return nil
+	}
+	d := *deprecation
+	e.deprecation = &d
+	return nil
+}
+
+func (e *Entry) Warnings(passed []string) []string {
+	defer e.rlock()()
+	warnings := e.entryWarnings(nil)
+	for _, name := range passed {
+		option := e.passedOption(name)
+		if option == nil {
+			continue
+		}
+		alias := name
+		if !option.hasAlias(alias) {
+			for _, a := range option.aliases {
+				if strings.TrimLeft(a, "-") == name {
+					alias = a
+				}
+			}
+		}
+		if warning := option.warning(alias); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+func (r *Result) Warnings() []string {
+	defer r.Entry.rlock()()
+	warnings := r.Entry.entryWarnings(r.Path)
+	for _, parsed := range r.Options {
+		if warning := parsed.Option.warning(parsed.Alias); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+func (e *Entry) entryWarnings(path []string) []string {
+	warnings := make([]string, 0)
+	depth := 0
+	for ptr := e; ptr != nil && (path == nil || depth < len(path)); ptr = ptr.parent {
+		if ptr.deprecation != nil {
+			warnings = append([]string{fmt.Sprintf("command '%s' is %s", ptr.path(), ptr.deprecation)}, warnings...)
+		}
+		depth++
+	}
+	return warnings
+}
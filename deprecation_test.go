@@ -0,0 +1,268 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type deprecationStringTester struct {
+	iDeprecation Deprecation
+	oString      string
+}
+
+func (tester deprecationStringTester) assertString() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.iDeprecation.String()
+		assertDeprecation(t, got, tester.oString)
+	}
+}
+
+type optionDeprecateAliasTester struct {
+	iAlias string
+	oErr   error
+}
+
+func (tester optionDeprecateAliasTester) assertDeprecated() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--addr", "--listen"}}
+		sampleOption.DeprecateAlias(tester.iAlias, Deprecation{Message: "old"})
+		gotErr := sampleOption.DeprecateAlias(tester.iAlias, Deprecation{Replacement: "--listen"})
+		assertNilError(t, gotErr)
+		got := sampleOption.AliasDeprecated(tester.iAlias)
+		assertDeprecation(t, got.String(), "deprecated: use --listen")
+		if len(sampleOption.aliasDeprecations) != 1 {
+			t.Errorf("%d alias deprecations stored but wanted 1", len(sampleOption.aliasDeprecations))
+		}
+		if sampleOption.AliasDeprecated("--listen") != nil {
+			t.Error("other alias was deprecated")
+		}
+	}
+}
+
+func (tester optionDeprecateAliasTester) assertNotFoundError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--addr", "--listen"}}
+		got := sampleOption.DeprecateAlias(tester.iAlias, Deprecation{})
+		assertNotFoundError(t, got, tester.oErr)
+	}
+}
+
+type optionSetDeprecatedTester struct {
+	iDeprecation *Deprecation
+}
+
+func (tester optionSetDeprecatedTester) assertDeprecated() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--addr"}, deprecation: &Deprecation{}}
+		sampleOption.SetDeprecated(tester.iDeprecation)
+		got := sampleOption.Deprecated()
+		if (got == nil) != (tester.iDeprecation == nil) || got != nil && *got != *tester.iDeprecation {
+			t.Errorf("deprecation is %v but should be %v", got, tester.iDeprecation)
+		}
+	}
+}
+
+type entrySetDeprecatedTester struct {
+	iDeprecation *Deprecation
+	oErr         error
+}
+
+func (tester entrySetDeprecatedTester) assertDeprecated() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", deprecation: &Deprecation{}}
+		gotErr := sampleEntry.SetDeprecated(tester.iDeprecation)
+		assertNilError(t, gotErr)
+		got := sampleEntry.Deprecated()
+		if (got == nil) != (tester.iDeprecation == nil) || got != nil && *got != *tester.iDeprecation {
+			t.Errorf("deprecation is %v but should be %v", got, tester.iDeprecation)
+		}
+	}
+}
+
+func (tester entrySetDeprecatedTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", tree: newSealedTree()}
+		got := sampleEntry.SetDeprecated(tester.iDeprecation)
+		assertSealedError(t, got, tester.oErr)
+		if sampleEntry.Deprecated() != nil {
+			t.Error("sealed entry was deprecated")
+		}
+	}
+}
+
+type entryWarningsTester struct {
+	iPath     []string
+	iPassed   []string
+	oWarnings []string
+}
+
+func (tester entryWarningsTester) assertWarnings() func(*testing.T) {
+	return func(t *testing.T) {
+		root := newPathTree()
+		root.options[1].SetDeprecated(&Deprecation{Replacement: "--config-file"})
+		root.options[2].DeprecateAlias("-o", Deprecation{Replacement: "--output"})
+		root.children["db"].SetDeprecated(&Deprecation{Replacement: "database", RemovalVersion: "v2.0.0"})
+		sampleEntry, _ := root.Find(tester.iPath...)
+		got := sampleEntry.Warnings(tester.iPassed)
+		assertWarnings(t, got, tester.oWarnings)
+	}
+}
+
+type resultWarningsTester struct {
+	iArgs     []string
+	oWarnings []string
+}
+
+func (tester resultWarningsTester) assertWarnings() func(*testing.T) {
+	return func(t *testing.T) {
+		root := newPathTree()
+		root.options[1].SetDeprecated(&Deprecation{Replacement: "--config-file"})
+		root.options[2].DeprecateAlias("-o", Deprecation{Replacement: "--output"})
+		root.children["db"].SetDeprecated(&Deprecation{Replacement: "database", RemovalVersion: "v2.0.0"})
+		result, _ := root.Parse(tester.iArgs)
+		got := result.Warnings()
+		assertWarnings(t, got, tester.oWarnings)
+	}
+}
+
+type setWarningOutputTester struct {
+	iArgs     []string
+	oWarnings string
+	oErr      error
+	oPanic    error
+}
+
+func (tester setWarningOutputTester) assertWarnings() func(*testing.T) {
+	return func(t *testing.T) {
+		root := newPathTree()
+		root.options[1].SetDeprecated(&Deprecation{Replacement: "--config-file"})
+		root.options[2].DeprecateAlias("-o", Deprecation{Replacement: "--output"})
+		root.children["db"].SetDeprecated(&Deprecation{Replacement: "database", RemovalVersion: "v2.0.0"})
+		global = &Program{root: root}
+		global.root.children["db"].children["create"].SetHandler(func(context.Context, *Invocation) error {
+			return nil
+		})
+		var b strings.Builder
+		gotErr := SetWarningOutput(&b)
+		assertNilError(t, gotErr)
+		assertNilError(t, Run(context.Background(), tester.iArgs))
+		assertWarningOutput(t, b.String(), tester.oWarnings)
+		global = nil
+	}
+}
+
+func (tester setWarningOutputTester) assertNoWriterError() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		got := SetWarningOutput(nil)
+		assertError(t, got, tester.oErr)
+		global = nil
+	}
+}
+
+func (tester setWarningOutputTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		SetWarningOutput(&strings.Builder{})
+		assertNilProgram(t, global)
+	}
+}
+
+func TestDeprecationString(t *testing.T) {
+	t.Run("baseline", deprecationStringTester{
+		iDeprecation: Deprecation{Replacement: "--listen"},
+		oString:      "deprecated: use --listen",
+	}.assertString())
+	t.Run("empty", deprecationStringTester{
+		oString: "deprecated",
+	}.assertString())
+	t.Run("all details", deprecationStringTester{
+		iDeprecation: Deprecation{
+			Message:        "addresses are now parsed strictly",
+			Replacement:    "--listen",
+			RemovalVersion: "v2.0.0",
+		},
+		oString: "deprecated: use --listen; will be removed in v2.0.0; addresses are now parsed strictly",
+	}.assertString())
+}
+
+func TestOptionSetDeprecated(t *testing.T) {
+	t.Run("baseline", optionSetDeprecatedTester{
+		iDeprecation: &Deprecation{Replacement: "--listen"},
+	}.assertDeprecated())
+	t.Run("not deprecated", optionSetDeprecatedTester{}.assertDeprecated())
+}
+
+func TestOptionDeprecateAlias(t *testing.T) {
+	t.Run("baseline", optionDeprecateAliasTester{
+		iAlias: "--addr",
+	}.assertDeprecated())
+	t.Run("untracked alias", optionDeprecateAliasTester{
+		iAlias: "--foo",
+		oErr:   errors.New("usage: alias '--foo' not found in option '--addr, --listen'"),
+	}.assertNotFoundError())
+}
+
+func TestEntrySetDeprecated(t *testing.T) {
+	t.Run("baseline", entrySetDeprecatedTester{
+		iDeprecation: &Deprecation{Replacement: "database"},
+	}.assertDeprecated())
+	t.Run("not deprecated", entrySetDeprecatedTester{}.assertDeprecated())
+	t.Run("sealed", entrySetDeprecatedTester{
+		iDeprecation: &Deprecation{Replacement: "database"},
+		oErr:         errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryWarnings(t *testing.T) {
+	t.Run("baseline", entryWarningsTester{
+		iPassed:   []string{"--config", "-v"},
+		oWarnings: []string{"option '--config' is deprecated: use --config-file"},
+	}.assertWarnings())
+	t.Run("flag names", entryWarningsTester{
+		iPassed: []string{"config", "o", "output"},
+		oWarnings: []string{
+			"option '--config' is deprecated: use --config-file",
+			"option '-o' is deprecated: use --output",
+		},
+	}.assertWarnings())
+	t.Run("deprecated ancestor", entryWarningsTester{
+		iPath:     []string{"db", "create"},
+		oWarnings: []string{"command 'base db' is deprecated: use database; will be removed in v2.0.0"},
+	}.assertWarnings())
+	t.Run("no warnings", entryWarningsTester{
+		iPath:     []string{"user"},
+		iPassed:   []string{"--foo"},
+		oWarnings: []string{},
+	}.assertWarnings())
+}
+
+func TestResultWarnings(t *testing.T) {
+	t.Run("baseline", resultWarningsTester{
+		iArgs: []string{"-o", "foo", "--config=foo.yml", "db", "create", "foo"},
+		oWarnings: []string{
+			"command 'base db' is deprecated: use database; will be removed in v2.0.0",
+			"option '-o' is deprecated: use --output",
+			"option '--config' is deprecated: use --config-file",
+		},
+	}.assertWarnings())
+	t.Run("no warnings", resultWarningsTester{
+		iArgs:     []string{"--output", "foo", "user", "create"},
+		oWarnings: []string{},
+	}.assertWarnings())
+}
+
+func TestSetWarningOutput(t *testing.T) {
+	t.Run("baseline", setWarningOutputTester{
+		iArgs:     []string{"db", "create", "foo"},
+		oWarnings: "warning: command 'base db' is deprecated: use database; will be removed in v2.0.0\n",
+	}.assertWarnings())
+	t.Run("no writer", setWarningOutputTester{
+		oErr: errors.New("usage: no writer provided"),
+	}.assertNoWriterError())
+	t.Run("uninitialized", setWarningOutputTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
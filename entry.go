@@ -3,9 +3,12 @@ package usage
 import (
 	_ "embed"
 	"errors"
+	"fmt"
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"text/template"
 )
 
@@ -13,58 +16,124 @@ import (
 var defaultEntryTmpl string
 
 type Entry struct {
-	Description string
-	tmpl        *template.Template
-	name        string
-	args        []string
-	options     []Option
-	children    map[string]*Entry
-	parent      *Entry
+	Description   string
+	tmpl          *template.Template
+	name          string
+	aliases       []string
+	args          []string
+	options       []Option
+	children      map[string]*Entry
+	parent        *Entry
+	matching      *Matching
+	handler       Handler
+	help          *Option
+	helpEntry     *Entry
+	version       *versionSetting
+	required      []string
+	exclusive     [][]string
+	together      [][]string
+	optionGroups  []string
+	category      string
+	categoryOrder []string
+	entryOrder    EntryLess
+	optionOrder   OptionLess
+	seq           int
+	nextSeq       int
+	hidden        bool
+	deprecation   *Deprecation
+	stability     Stability
+	gate          *Gate
+	examples      []Example
+	sections      []Section
+	footerText    *string
+	codes         []ExitCode
+	tree          *tree
 }
 
-func (e Entry) Args() []string {
-	return e.args
+type tree struct {
+	mu     sync.RWMutex
+	sealed atomic.Bool
+	merged atomic.Pointer[tree]
 }
 
-func (e Entry) Options() []Option {
-	return e.options
+func (t *tree) resolve() *tree {
+	for next := t.merged.Load(); next != nil; next = t.merged.Load() {
+		t = next
+	}
+	return t
+}
+
+func (e *Entry) Args() []string {
+	defer e.rlock()()
+	return append(make([]string, 0, len(e.args)), e.args...)
+}
+
+func (e *Entry) Options() []Option {
+	defer e.rlock()()
+	output := append(make([]Option, 0, len(e.options)), e.options...)
+	e.sortOptions(output)
+	return output
 }
 
-func (e Entry) Entries() []Entry {
+func (e *Entry) GlobalOptions() []Option {
+	defer e.rlock()()
+	return e.globalOptions()
+}
+
+func (e *Entry) Entries() []Entry {
+	defer e.rlock()()
+	return e.entries()
+}
+
+func (e *Entry) entries() []Entry {
 	output := make([]Entry, 0)
 	for _, v := range e.children {
 		output = append(output, *v)
 	}
-	sort.Slice(output, func(i, j int) bool {
-		return output[i].name < output[j].name
-	})
+	e.sortEntries(output)
 	return output
 }
 
-func (e Entry) Name() string {
+func (e *Entry) Name() string {
+	defer e.rlock()()
 	return e.name
 }
 
-func (e Entry) Ancestry() []string {
-	ancestry := []string{e.name}
-	for ptr := &e; ptr.parent != nil; ptr = ptr.parent {
-		ancestry = append(ancestry, ptr.parent.name)
-	}
-	return ancestry
+func (e *Entry) Ancestry() []string {
+	defer e.rlock()()
+	return e.ancestry()
+}
+
+func (e *Entry) Aliases() []string {
+	defer e.rlock()()
+	return append(make([]string, 0, len(e.aliases)), e.aliases...)
+}
+
+func (e *Entry) Sealed() bool {
+	return e.tree != nil && e.tree.resolve().sealed.Load()
 }
 
 func (e *Entry) AddArg(arg string) error {
-	if len(e.children) > 0 {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if len(e.children) > 0 && !e.onlyBuiltinChildren() {
 		return &UsageError{errors.New("cannot add arg with child entries present")}
 	}
 	if arg == "" {
 		return &UsageError{errors.New("arg string must not be empty")}
 	}
+	e.dropBuiltinChildren()
 	e.args = append(e.args, arg)
 	return nil
 }
 
 func (e *Entry) AddOption(option *Option) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
 	if option == nil {
 		return &UsageError{errors.New("no option provided")}
 	}
@@ -81,55 +150,374 @@ func (e *Entry) AddOption(option *Option) error {
 }
 
 func (e *Entry) AddEntry(entry *Entry) error {
+	defer e.lock()()
+	if entry != nil && !e.sameTree(entry) {
+		defer entry.lock()()
+	}
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
 	if entry == nil {
 		return &UsageError{errors.New("no entry provided")}
 	}
+	if entry.Sealed() {
+		return &UsageError{errors.New("cannot add sealed child entry")}
+	}
 	if entry.name == "" {
 		return &UsageError{errors.New("name string must not be empty")}
 	}
 	if len(e.args) > 0 {
 		return &UsageError{errors.New("cannot add child entry with args present")}
 	}
-	entry.parent = e
-	e.children[entry.name] = entry
+	if err := e.checkConflicts(entry, entry.names()); err != nil {
+		return err
+	}
+	e.addChild(entry)
 	return nil
 }
 
 func (e *Entry) SetName(name string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
 	if name == "" {
 		return &UsageError{errors.New("name string must not be empty")}
 	}
+	if err := e.checkNames(append([]string{name}, e.aliases...)); err != nil {
+		return err
+	}
+	if e.parent != nil {
+		delete(e.parent.children, e.name)
+		e.parent.children[name] = e
+	}
 	e.name = name
 	return nil
 }
 
-func (e Entry) Usage() string {
-	var b strings.Builder
-	e.tmpl.Execute(&b, e)
-	return b.String()
+func (e *Entry) SetAliases(aliases []string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	for _, alias := range aliases {
+		if len(alias) == 0 {
+			return &UsageError{errors.New("alias string must not be empty")}
+		}
+	}
+	if err := e.checkNames(append([]string{e.name}, aliases...)); err != nil {
+		return err
+	}
+	e.aliases = aliases
+	return nil
+}
+
+func (e *Entry) Usage() string {
+	return e.render(false)
+}
+
+func (e *Entry) UsageAll() string {
+	return e.render(true)
 }
 
 func (e *Entry) Lookup(lookup string) string {
 	if lookup == "" {
 		return lookup
 	}
-	if lookup == e.name {
-		return e.Usage()
+	unlock := e.rlock()
+	found := e
+	if lookup != e.name {
+		found, _ = e.search(lookup)
+	}
+	unlock()
+	if found == nil {
+		return ""
 	}
-	var u string
+	return found.Usage()
+}
+
+func (e *Entry) Find(path ...string) (*Entry, error) {
+	defer e.rlock()()
+	return e.find(path)
+}
+
+func (e *Entry) Seal() error {
+	defer e.lock()()
+	if e.Sealed() {
+		return nil
+	}
+	root := e
+	for root.parent != nil {
+		root = root.parent
+	}
+	if err := validate(root); err != nil {
+		return err
+	}
+	if root.tree == nil {
+		t := new(tree)
+		visit(root, func(c *Entry) {
+			c.tree = t
+		})
+	}
+	root.tree.resolve().sealed.Store(true)
+	return nil
+}
+
+func (e *Entry) Hidden() bool {
+	defer e.rlock()()
+	return e.hidden
+}
+
+func (e *Entry) SetHidden(hidden bool) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	e.hidden = hidden
+	return nil
+}
+
+func (e *Entry) render(all bool) string {
+	s := e.snapshot(all)
+	var b strings.Builder
+	s.tmpl.Execute(&b, s)
+	return b.String()
+}
+
+func (e *Entry) setTemplate(tmpl *template.Template) {
+	e.tmpl = tmpl
+}
+
+func (e *Entry) find(path []string) (*Entry, error) {
+	ptr := e
+	for _, name := range path {
+		child, err := ptr.match(name)
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			return nil, &UsageError{fmt.Errorf("entry '%s' not found in '%s'", name, ptr.path())}
+		}
+		ptr = child
+	}
+	return ptr, nil
+}
+
+func (e *Entry) search(name string) (*Entry, error) {
+	matches := make([]*Entry, 0)
+	var err error
 	visit(e, func(entry *Entry) {
-		if len(entry.children) == 0 {
-			return
+		child, matchErr := entry.match(name)
+		if matchErr != nil && err == nil {
+			err = matchErr
 		}
-		if child, ok := entry.children[lookup]; ok {
-			u = child.Usage()
+		if child != nil {
+			matches = append(matches, child)
 		}
 	})
-	return u
+	if err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		return nil, &UsageError{fmt.Errorf("entry '%s' not found in '%s'", name, e.path())}
+	case 1:
+		return matches[0], nil
+	}
+	return nil, ambiguousError(name, matches)
 }
 
-func (e *Entry) setTemplate(tmpl *template.Template) {
-	e.tmpl = tmpl
+func (e *Entry) child(name string) *Entry {
+	if child, ok := e.children[name]; ok && !child.gated() {
+		return child
+	}
+	for _, child := range e.children {
+		if child.gated() {
+			continue
+		}
+		for _, alias := range child.aliases {
+			if alias == name {
+				return child
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Entry) names() []string {
+	return append([]string{e.name}, e.aliases...)
+}
+
+func (e *Entry) checkNames(names []string) error {
+	if e.parent != nil {
+		return e.parent.checkConflicts(e, names)
+	}
+	if name := duplicateName(names); name != "" {
+		return &UsageError{fmt.Errorf("entry name or alias '%s' already in use in '%s'", name, e.path())}
+	}
+	return nil
+}
+
+func (e *Entry) checkConflicts(entry *Entry, names []string) error {
+	if name := duplicateName(names); name != "" {
+		return &UsageError{fmt.Errorf("entry name or alias '%s' already in use in '%s'", name, e.path())}
+	}
+	for _, child := range e.children {
+		if child == entry {
+			continue
+		}
+		for _, used := range child.names() {
+			for _, name := range names {
+				if name == used {
+					return &UsageError{fmt.Errorf("entry name or alias '%s' already in use in '%s'", name, e.path())}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func duplicateName(names []string) string {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			return name
+		}
+		seen[name] = true
+	}
+	return ""
+}
+
+func (e *Entry) globalOptions() []Option {
+	output := make([]Option, 0)
+	shadowed := func(option Option) bool {
+		if e.ownsAlias(option) {
+			return true
+		}
+		for _, o := range output {
+			for _, alias := range option.aliases {
+				if o.hasAlias(alias) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for ptr := e.parent; ptr != nil; ptr = ptr.parent {
+		for _, option := range ptr.options {
+			if option.persistent && !shadowed(option) && !ptr.optionGated(option) {
+				output = append(output, option)
+			}
+		}
+	}
+	return output
+}
+
+func (e *Entry) ancestry() []string {
+	ancestry := []string{e.name}
+	for ptr := e; ptr.parent != nil; ptr = ptr.parent {
+		ancestry = append(ancestry, ptr.parent.name)
+	}
+	return ancestry
+}
+
+func (e *Entry) path() string {
+	return strings.Join(reverseAncestryChain(e.ancestry()), " ")
+}
+
+func (e *Entry) lock() func() {
+	if e.tree == nil || e.Sealed() {
+		return func() {}
+	}
+	for {
+		t := e.tree.resolve()
+		t.mu.Lock()
+		if t.merged.Load() == nil {
+			return t.mu.Unlock
+		}
+		t.mu.Unlock()
+	}
+}
+
+func (e *Entry) rlock() func() {
+	if e.tree == nil || e.Sealed() {
+		return func() {}
+	}
+	for {
+		t := e.tree.resolve()
+		t.mu.RLock()
+		if t.merged.Load() == nil {
+			return t.mu.RUnlock
+		}
+		t.mu.RUnlock()
+	}
+}
+
+func (e *Entry) sameTree(entry *Entry) bool {
+	return e.tree != nil && entry.tree != nil && e.tree.resolve() == entry.tree.resolve()
+}
+
+func (e *Entry) snapshot(all bool) *Entry {
+	defer e.rlock()()
+	s := e.clone(all)
+	ptr := s
+	for p := e.parent; p != nil; p = p.parent {
+		ptr.parent = p.copy(all)
+		ptr = ptr.parent
+	}
+	return s
+}
+
+func (e *Entry) clone(all bool) *Entry {
+	c := e.copy(all)
+	for name, child := range e.children {
+		if child.hidden && !all || child.gated() {
+			continue
+		}
+		cc := child.clone(all)
+		cc.parent = c
+		c.children[name] = cc
+		if child == e.helpEntry {
+			c.helpEntry = cc
+		}
+		if e.version != nil && child == e.version.entry {
+			v := *e.version
+			v.entry = cc
+			c.version = &v
+		}
+	}
+	return c
+}
+
+func (e *Entry) copy(all bool) *Entry {
+	c := *e
+	c.tree = nil
+	c.parent = nil
+	c.args = append(make([]string, 0, len(e.args)), e.args...)
+	c.examples = append(make([]Example, 0, len(e.examples)), e.examples...)
+	c.sections = append(make([]Section, 0, len(e.sections)), e.sections...)
+	c.codes = append(make([]ExitCode, 0, len(e.codes)), e.codes...)
+	c.options = make([]Option, 0, len(e.options)+1)
+	for _, option := range e.options {
+		if (!option.hidden || all) && !e.optionGated(option) {
+			c.options = append(c.options, option)
+		}
+	}
+	if help := e.helpOption(); help != nil && !e.ownsAlias(*help) {
+		c.options = append(c.options, *help)
+	}
+	for i := range c.options {
+		c.options[i].notes = append(e.optionNotes(c.options[i]), c.options[i].deprecationNotes()...)
+		if c.options[i].stability != Stable {
+			c.options[i].notes = append(c.options[i].notes, c.options[i].stability.String())
+		}
+		if c.options[i].hidden {
+			c.options[i].notes = append(c.options[i].notes, "hidden")
+		}
+	}
+	e.sortOptions(c.options)
+	c.children = make(map[string]*Entry)
+	return &c
 }
 
 func NewEntry(name, desc string) (*Entry, error) {
@@ -143,6 +531,7 @@ func NewEntry(name, desc string) (*Entry, error) {
 				"reverse": reverseAncestryChain,
 				"summary": deriveSummaryString,
 				"chop":    chopEssay,
+				"wrap":    wrapCommand,
 				"sub": func(a, b int) int {
 					return a - b
 				},
@@ -156,6 +545,7 @@ func NewEntry(name, desc string) (*Entry, error) {
 		args:        make([]string, 0),
 		options:     make([]Option, 0),
 		children:    make(map[string]*Entry),
+		tree:        new(tree),
 	}, nil
 }
 
@@ -202,13 +592,14 @@ func deriveSummaryString(entry Entry) string {
 	if len(entry.children) > 0 {
 		b.WriteString(" <command>")
 	}
-	if len(entry.options) > 0 {
+	if len(entry.options) > 0 || len(entry.GlobalOptions()) > 0 {
 		b.WriteString(" [options]")
 	}
+	b.WriteString(entry.constraintSummary())
 	if len(entry.children) > 0 {
 		foundArgs := false
 		visit(&entry, func(e *Entry) {
-			foundArgs = len(e.args) > 0
+			foundArgs = foundArgs || len(e.args) > 0 && !e.isHelpEntry() && !e.isVersionEntry()
 		})
 		if foundArgs {
 			b.WriteString(" <args>")
@@ -219,6 +610,60 @@ func deriveSummaryString(entry Entry) string {
 	return b.String()
 }
 
+func validate(entry *Entry) error {
+	if entry.name == "" {
+		return &UsageError{errors.New("name string must not be empty")}
+	}
+	if entry.tmpl == nil {
+		return &UsageError{fmt.Errorf("entry '%s' has no template", entry.path())}
+	}
+	if len(entry.args) > 0 && len(entry.children) > 0 {
+		return &UsageError{fmt.Errorf("entry '%s' has both args and child entries", entry.path())}
+	}
+	names := make([]string, 0, len(entry.children))
+	for name := range entry.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	used := make(map[string]bool)
+	for _, name := range names {
+		for _, n := range entry.children[name].names() {
+			if used[n] {
+				return &UsageError{fmt.Errorf("entry name or alias '%s' already in use in '%s'", n, entry.path())}
+			}
+			used[n] = true
+		}
+	}
+	if entry.help != nil && entry.help.tmpl == nil {
+		return &UsageError{fmt.Errorf("option '%s' in '%s' has no template", strings.Join(entry.help.aliases, ", "), entry.path())}
+	}
+	seen := make(map[string]bool)
+	for ptr := entry.parent; ptr != nil; ptr = ptr.parent {
+		for _, option := range ptr.options {
+			for _, alias := range option.aliases {
+				seen[alias] = seen[alias] || option.persistent
+			}
+		}
+	}
+	for _, option := range entry.options {
+		if option.tmpl == nil {
+			return &UsageError{fmt.Errorf("option '%s' in '%s' has no template", strings.Join(option.aliases, ", "), entry.path())}
+		}
+		for _, alias := range option.aliases {
+			if seen[alias] {
+				return &UsageError{fmt.Errorf("duplicate option alias '%s' in '%s'", alias, entry.path())}
+			}
+			seen[alias] = true
+		}
+	}
+	for _, name := range names {
+		if err := validate(entry.children[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func visit(entry *Entry, fn func(e *Entry)) {
 	fn(entry)
 	for _, c := range entry.children {
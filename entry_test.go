@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"text/template"
 )
@@ -63,6 +64,18 @@ func (tester entryNameTester) assertName() func(*testing.T) {
 	}
 }
 
+type entryAliasesTester struct {
+	oAliases []string
+}
+
+func (tester entryAliasesTester) assertAliases() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := Entry{aliases: tester.oAliases}
+		got := sampleEntry.Aliases()
+		assertAliases(t, got, tester.oAliases)
+	}
+}
+
 type entryAncestryTester struct {
 	oAncestry []string
 }
@@ -122,6 +135,15 @@ func (tester entryAddArgTester) assertExistingEntriesError() func(*testing.T) {
 	}
 }
 
+func (tester entryAddArgTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{args: make([]string, 0), tree: newSealedTree()}
+		got := sampleEntry.AddArg(tester.iArg)
+		assertSealedError(t, got, tester.oErr)
+		assertArgs(t, sampleEntry.args, []string{})
+	}
+}
+
 type entryAddOptionTester struct {
 	iOption *Option
 	oErr    error
@@ -165,6 +187,15 @@ func (tester entryAddOptionTester) assertEmptyAliasStringError() func(*testing.T
 	}
 }
 
+func (tester entryAddOptionTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{options: make([]Option, 0), tree: newSealedTree()}
+		got := sampleEntry.AddOption(tester.iOption)
+		assertSealedError(t, got, tester.oErr)
+		assertOptions(t, sampleEntry.options, []Option{})
+	}
+}
+
 type entryAddEntryTester struct {
 	iEntry *Entry
 	oErr   error
@@ -214,6 +245,40 @@ func (tester entryAddEntryTester) assertExistingArgsError() func(*testing.T) {
 	}
 }
 
+func (tester entryAddEntryTester) assertConflictError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name: "base",
+			children: map[string]*Entry{
+				"remove": {name: "remove", aliases: []string{"rm"}},
+			},
+		}
+		got := sampleEntry.AddEntry(tester.iEntry)
+		assertConflictError(t, got, tester.oErr)
+		assertParent(t, tester.iEntry.parent, nil)
+	}
+}
+
+func (tester entryAddEntryTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{children: make(map[string]*Entry), tree: newSealedTree()}
+		got := sampleEntry.AddEntry(tester.iEntry)
+		assertSealedError(t, got, tester.oErr)
+		assertChildren(t, sampleEntry.children, map[string]*Entry{})
+	}
+}
+
+func (tester entryAddEntryTester) assertSealedChildError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{children: make(map[string]*Entry)}
+		child := *tester.iEntry
+		child.tree = newSealedTree()
+		got := sampleEntry.AddEntry(&child)
+		assertSealedError(t, got, tester.oErr)
+		assertParent(t, child.parent, nil)
+	}
+}
+
 type entrySetNameTester struct {
 	iName string
 	oErr  error
@@ -236,6 +301,122 @@ func (tester entrySetNameTester) assertEmptyNameStringError() func(*testing.T) {
 	}
 }
 
+func (tester entrySetNameTester) assertChildren() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base", children: make(map[string]*Entry)}
+		child := &Entry{name: "foo", parent: sampleEntry}
+		sampleEntry.children["foo"] = child
+		gotErr := child.SetName(tester.iName)
+		assertNilError(t, gotErr)
+		assertChildren(t, sampleEntry.children, map[string]*Entry{tester.iName: child})
+	}
+}
+
+func (tester entrySetNameTester) assertConflictError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base", children: make(map[string]*Entry)}
+		child := &Entry{name: "foo", aliases: []string{"f"}, parent: sampleEntry}
+		sampleEntry.children["foo"] = child
+		sampleEntry.children["bar"] = &Entry{name: "bar", aliases: []string{"baz"}, parent: sampleEntry}
+		got := child.SetName(tester.iName)
+		assertConflictError(t, got, tester.oErr)
+		assertName(t, child.name, "foo")
+	}
+}
+
+func (tester entrySetNameTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := Entry{name: "foo", tree: newSealedTree()}
+		got := sampleEntry.SetName(tester.iName)
+		assertSealedError(t, got, tester.oErr)
+		assertName(t, sampleEntry.name, "foo")
+	}
+}
+
+type entrySetHiddenTester struct {
+	iHidden bool
+	oErr    error
+}
+
+func (tester entrySetHiddenTester) assertHidden() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo"}
+		gotErr := sampleEntry.SetHidden(tester.iHidden)
+		assertNilError(t, gotErr)
+		if got := sampleEntry.Hidden(); got != tester.iHidden {
+			t.Errorf("hidden is %t but should be %t", got, tester.iHidden)
+		}
+	}
+}
+
+func (tester entrySetHiddenTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", tree: newSealedTree()}
+		got := sampleEntry.SetHidden(tester.iHidden)
+		assertSealedError(t, got, tester.oErr)
+		if sampleEntry.Hidden() {
+			t.Error("sealed entry was hidden")
+		}
+	}
+}
+
+type entrySetAliasesTester struct {
+	iAliases []string
+	oErr     error
+}
+
+func (tester entrySetAliasesTester) assertAliases() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base", children: make(map[string]*Entry)}
+		child := &Entry{name: "foo", parent: sampleEntry}
+		sampleEntry.children["foo"] = child
+		gotErr := child.SetAliases(tester.iAliases)
+		assertNilError(t, gotErr)
+		assertAliases(t, child.aliases, tester.iAliases)
+		for _, alias := range tester.iAliases {
+			assertParent(t, sampleEntry.child(alias), child)
+		}
+	}
+}
+
+func (tester entrySetAliasesTester) assertEmptyAliasStringError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo"}
+		got := sampleEntry.SetAliases(tester.iAliases)
+		assertEmptyAliasStringError(t, got, tester.oErr)
+	}
+}
+
+func (tester entrySetAliasesTester) assertConflictError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base", children: make(map[string]*Entry)}
+		child := &Entry{name: "foo", parent: sampleEntry}
+		sampleEntry.children["foo"] = child
+		sampleEntry.children["bar"] = &Entry{name: "bar", aliases: []string{"baz"}, parent: sampleEntry}
+		got := child.SetAliases(tester.iAliases)
+		assertConflictError(t, got, tester.oErr)
+		assertAliases(t, child.aliases, []string{})
+	}
+}
+
+func (tester entrySetAliasesTester) assertOrphanConflictError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo"}
+		got := sampleEntry.SetAliases(tester.iAliases)
+		assertConflictError(t, got, tester.oErr)
+		assertAliases(t, sampleEntry.aliases, []string{})
+	}
+}
+
+func (tester entrySetAliasesTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", tree: newSealedTree()}
+		got := sampleEntry.SetAliases(tester.iAliases)
+		assertSealedError(t, got, tester.oErr)
+		assertAliases(t, sampleEntry.aliases, []string{})
+	}
+}
+
 type entryUsageTester struct {
 	oUsage string
 }
@@ -248,6 +429,30 @@ func (tester entryUsageTester) assertUsage() func(*testing.T) {
 	}
 }
 
+type entryDefaultUsageTester struct {
+	iEntry *Entry
+	oUsage string
+}
+
+func (tester entryDefaultUsageTester) assertUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.iEntry.Usage()
+		assertUsage(t, got, tester.oUsage)
+	}
+}
+
+type entryDefaultUsageAllTester struct {
+	iEntry *Entry
+	oUsage string
+}
+
+func (tester entryDefaultUsageAllTester) assertUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.iEntry.UsageAll()
+		assertUsage(t, got, tester.oUsage)
+	}
+}
+
 type entryLookupTester struct {
 	iLookup string
 	oUsage  string
@@ -285,6 +490,176 @@ func (tester entryLookupTester) assertUsage() func(*testing.T) {
 	}
 }
 
+type entryGlobalOptionsTester struct {
+	iPath    []string
+	oOptions []string
+}
+
+func (tester entryGlobalOptionsTester) assertOptions() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.options[0].SetPersistent(true)
+		db := sampleEntry.children["db"]
+		db.options[0].SetPersistent(true)
+		db.AddOption(&Option{aliases: []string{"--verbose"}})
+		entry, _ := sampleEntry.Find(tester.iPath...)
+		got := make([]string, 0)
+		for _, option := range entry.GlobalOptions() {
+			got = append(got, strings.Join(option.aliases, ","))
+		}
+		assertAliases(t, got, tester.oOptions)
+	}
+}
+
+type entrySealTester struct {
+	iEntry *Entry
+	oErr   error
+}
+
+func (tester entrySealTester) assertSealed() func(*testing.T) {
+	return func(t *testing.T) {
+		leaf := tester.iEntry
+		for len(leaf.children) > 0 {
+			for _, child := range leaf.children {
+				leaf = child
+			}
+		}
+		gotErr := leaf.Seal()
+		assertNilError(t, gotErr)
+		visit(tester.iEntry, func(e *Entry) {
+			assertSealed(t, e.Sealed(), true)
+		})
+		assertNilError(t, leaf.Seal())
+		if got := tester.iEntry.Usage(); got == "" {
+			t.Error("sealed entry rendered an empty usage")
+		}
+	}
+}
+
+func (tester entrySealTester) assertInvalidTreeError() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.iEntry.Seal()
+		assertInvalidTreeError(t, got, tester.oErr)
+		visit(tester.iEntry, func(e *Entry) {
+			assertSealed(t, e.Sealed(), false)
+		})
+	}
+}
+
+type entryConcurrencyTester struct {
+	iWorkers int
+}
+
+func (tester entryConcurrencyTester) assertTree() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry, _ := NewEntry("base", "")
+		var wg sync.WaitGroup
+		for i := 0; i < tester.iWorkers; i++ {
+			wg.Add(4)
+			go func(i int) {
+				defer wg.Done()
+				child, _ := NewEntry(fmt.Sprintf("child-%d", i), "")
+				option, _ := NewOption([]string{"--foo"}, "")
+				child.AddOption(option)
+				assertNilError(t, sampleEntry.AddEntry(child))
+				child.AddArg("<foo>")
+			}(i)
+			go func() {
+				defer wg.Done()
+				option, _ := NewOption([]string{"--bar"}, "")
+				assertNilError(t, sampleEntry.AddOption(option))
+			}()
+			go func() {
+				defer wg.Done()
+				sampleEntry.Usage()
+				sampleEntry.Entries()
+				sampleEntry.Options()
+			}()
+			go func(i int) {
+				defer wg.Done()
+				sampleEntry.Lookup(fmt.Sprintf("child-%d", i))
+				sampleEntry.Ancestry()
+			}(i)
+		}
+		wg.Wait()
+		if got := len(sampleEntry.Entries()); got != tester.iWorkers {
+			t.Errorf("%d children added but wanted %d", got, tester.iWorkers)
+		}
+		if got := len(sampleEntry.Options()); got != tester.iWorkers {
+			t.Errorf("%d options added but wanted %d", got, tester.iWorkers)
+		}
+		for _, child := range sampleEntry.Entries() {
+			assertArgs(t, child.Args(), []string{"<foo>"})
+		}
+	}
+}
+
+func (tester entryConcurrencyTester) assertSubtree() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry, _ := NewEntry("base", "")
+		children := make([]*Entry, 0, tester.iWorkers)
+		var wg sync.WaitGroup
+		for i := 0; i < tester.iWorkers; i++ {
+			child, _ := NewEntry(fmt.Sprintf("child-%d", i), "")
+			children = append(children, child)
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				assertNilError(t, sampleEntry.AddEntry(child))
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < tester.iWorkers; j++ {
+					grandchild, _ := NewEntry(fmt.Sprintf("grandchild-%d", j), "")
+					assertNilError(t, child.AddEntry(grandchild))
+					child.Usage()
+				}
+			}()
+		}
+		wg.Wait()
+		for _, child := range children {
+			if got := len(child.Entries()); got != tester.iWorkers {
+				t.Errorf("%d grandchildren added but wanted %d", got, tester.iWorkers)
+			}
+			if got, _ := sampleEntry.Find(child.Name()); got != child {
+				t.Errorf("child %q was not attached", child.Name())
+			}
+		}
+	}
+}
+
+type entryFindTester struct {
+	iPath  []string
+	oUsage string
+	oErr   error
+}
+
+func (tester entryFindTester) assertEntry() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		got, gotErr := sampleEntry.Find(tester.iPath...)
+		assertNilError(t, gotErr)
+		assertUsage(t, got.Usage(), tester.oUsage)
+	}
+}
+
+func (tester entryFindTester) assertNotFoundError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		gotEntry, got := sampleEntry.Find(tester.iPath...)
+		assertNilEntry(t, gotEntry)
+		assertNotFoundError(t, got, tester.oErr)
+	}
+}
+
+func (tester entryLookupTester) assertPathTreeUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		got := sampleEntry.Lookup(tester.iLookup)
+		assertUsage(t, got, tester.oUsage)
+	}
+}
+
 type newEntryTester struct {
 	iName        string
 	iDescription string
@@ -410,6 +785,18 @@ func TestEntryName(t *testing.T) {
 	}.assertName())
 }
 
+func TestEntryAliases(t *testing.T) {
+	t.Run("baseline", entryAliasesTester{
+		oAliases: []string{"foo"},
+	}.assertAliases())
+	t.Run("multiple aliases", entryAliasesTester{
+		oAliases: []string{"foo", "bar"},
+	}.assertAliases())
+	t.Run("no aliases", entryAliasesTester{
+		oAliases: make([]string, 0),
+	}.assertAliases())
+}
+
 func TestEntryAncestry(t *testing.T) {
 	t.Run("baseline", entryAncestryTester{
 		oAncestry: []string{"foo", "bar", "baz"},
@@ -429,6 +816,10 @@ func TestEntryAddArg(t *testing.T) {
 	t.Run("existing entries", entryAddArgTester{
 		oErr: errors.New("usage: cannot add arg with child entries present"),
 	}.assertExistingEntriesError())
+	t.Run("sealed", entryAddArgTester{
+		iArg: "foo",
+		oErr: errors.New("usage: entry is sealed"),
+	}.assertSealedError())
 }
 
 func TestEntryAddOption(t *testing.T) {
@@ -458,6 +849,10 @@ func TestEntryAddOption(t *testing.T) {
 		iOption: &Option{aliases: []string{"foo", "", "bar", ""}},
 		oErr:    errors.New("usage: alias string must not be empty"),
 	}.assertEmptyAliasStringError())
+	t.Run("sealed", entryAddOptionTester{
+		iOption: &Option{aliases: []string{"foo"}},
+		oErr:    errors.New("usage: entry is sealed"),
+	}.assertSealedError())
 }
 
 func TestEntryAddEntry(t *testing.T) {
@@ -475,6 +870,34 @@ func TestEntryAddEntry(t *testing.T) {
 		iEntry: &Entry{name: "foo"},
 		oErr:   errors.New("usage: cannot add child entry with args present"),
 	}.assertExistingArgsError())
+	t.Run("duplicate name", entryAddEntryTester{
+		iEntry: &Entry{name: "remove"},
+		oErr:   errors.New("usage: entry name or alias 'remove' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("name matches alias", entryAddEntryTester{
+		iEntry: &Entry{name: "rm"},
+		oErr:   errors.New("usage: entry name or alias 'rm' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("alias matches alias", entryAddEntryTester{
+		iEntry: &Entry{name: "delete", aliases: []string{"del", "rm"}},
+		oErr:   errors.New("usage: entry name or alias 'rm' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("alias matches own name", entryAddEntryTester{
+		iEntry: &Entry{name: "zap", aliases: []string{"zap"}},
+		oErr:   errors.New("usage: entry name or alias 'zap' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("repeated alias", entryAddEntryTester{
+		iEntry: &Entry{name: "zap", aliases: []string{"z", "z"}},
+		oErr:   errors.New("usage: entry name or alias 'z' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("sealed", entryAddEntryTester{
+		iEntry: &Entry{name: "foo"},
+		oErr:   errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+	t.Run("sealed child", entryAddEntryTester{
+		iEntry: &Entry{name: "foo"},
+		oErr:   errors.New("usage: cannot add sealed child entry"),
+	}.assertSealedChildError())
 }
 
 func TestEntrySetName(t *testing.T) {
@@ -484,6 +907,426 @@ func TestEntrySetName(t *testing.T) {
 	t.Run("empty name string", entrySetNameTester{
 		oErr: errors.New("usage: name string must not be empty"),
 	}.assertEmptyNameStringError())
+	t.Run("attached", entrySetNameTester{
+		iName: "qux",
+	}.assertChildren())
+	t.Run("sibling name", entrySetNameTester{
+		iName: "bar",
+		oErr:  errors.New("usage: entry name or alias 'bar' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("sibling alias", entrySetNameTester{
+		iName: "baz",
+		oErr:  errors.New("usage: entry name or alias 'baz' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("own alias", entrySetNameTester{
+		iName: "f",
+		oErr:  errors.New("usage: entry name or alias 'f' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("sealed", entrySetNameTester{
+		iName: "bar",
+		oErr:  errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntrySetHidden(t *testing.T) {
+	t.Run("baseline", entrySetHiddenTester{
+		iHidden: true,
+	}.assertHidden())
+	t.Run("visible", entrySetHiddenTester{}.assertHidden())
+	t.Run("sealed", entrySetHiddenTester{
+		iHidden: true,
+		oErr:    errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntrySetAliases(t *testing.T) {
+	t.Run("baseline", entrySetAliasesTester{
+		iAliases: []string{"f"},
+	}.assertAliases())
+	t.Run("multiple aliases", entrySetAliasesTester{
+		iAliases: []string{"f", "fo"},
+	}.assertAliases())
+	t.Run("no aliases", entrySetAliasesTester{
+		iAliases: make([]string, 0),
+	}.assertAliases())
+	t.Run("single empty alias string", entrySetAliasesTester{
+		iAliases: []string{""},
+		oErr:     errors.New("usage: alias string must not be empty"),
+	}.assertEmptyAliasStringError())
+	t.Run("multiple empty alias strings", entrySetAliasesTester{
+		iAliases: []string{"foo", "", "bar", ""},
+		oErr:     errors.New("usage: alias string must not be empty"),
+	}.assertEmptyAliasStringError())
+	t.Run("sibling name", entrySetAliasesTester{
+		iAliases: []string{"f", "bar"},
+		oErr:     errors.New("usage: entry name or alias 'bar' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("sibling alias", entrySetAliasesTester{
+		iAliases: []string{"baz"},
+		oErr:     errors.New("usage: entry name or alias 'baz' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("own name", entrySetAliasesTester{
+		iAliases: []string{"foo"},
+		oErr:     errors.New("usage: entry name or alias 'foo' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("repeated alias", entrySetAliasesTester{
+		iAliases: []string{"f", "f"},
+		oErr:     errors.New("usage: entry name or alias 'f' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("own name without parent", entrySetAliasesTester{
+		iAliases: []string{"foo"},
+		oErr:     errors.New("usage: entry name or alias 'foo' already in use in 'foo'"),
+	}.assertOrphanConflictError())
+	t.Run("sealed", entrySetAliasesTester{
+		iAliases: []string{"f"},
+		oErr:     errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryDefaultUsage(t *testing.T) {
+	const indent = "    "
+	newEntry := func(name, desc string, aliases []string, args ...string) *Entry {
+		entry, _ := NewEntry(name, desc)
+		entry.SetAliases(aliases)
+		for _, arg := range args {
+			entry.AddArg(arg)
+		}
+		return entry
+	}
+	newOption := func(aliases ...string) *Option {
+		option, _ := NewOption(aliases, "")
+		return option
+	}
+
+	aliased, _ := NewEntry("base", "")
+	aliased.AddEntry(newEntry("list", "list things", []string{"ls"}))
+	aliased.AddEntry(newEntry("remove", "remove a thing", []string{"rm", "del"}, "<name>"))
+
+	t.Run("aliases", entryDefaultUsageTester{
+		iEntry: aliased,
+		oUsage: "Usage:\n" +
+			indent + "base <command> <args>\n" +
+			"\n" +
+			indent + "To learn more about the available options for each command,\n" +
+			indent + "use the --help flag like so:\n" +
+			"\n" +
+			indent + "base <command> --help\n" +
+			"\n" +
+			"Commands:\n" +
+			indent + "list, ls\n" +
+			indent + indent + "list things\n" +
+			indent + "remove, rm, del <name>\n" +
+			indent + indent + "remove a thing",
+	}.assertUsage())
+
+	constrained, _ := NewEntry("deploy", "")
+	for _, aliases := range [][]string{{"-t", "--token"}, {"--json"}, {"--yaml"}, {"--cert"}, {"--key"}} {
+		option, _ := NewOption(aliases, "")
+		if aliases[0] == "-t" || aliases[0] == "--cert" {
+			option.AddArg("<file>")
+		}
+		constrained.AddOption(option)
+	}
+	constrained.RequireOption("--token")
+	constrained.AddExclusiveGroup("--json", "--yaml")
+	constrained.AddCoRequiredGroup("--cert", "--key")
+
+	persistent, _ := NewEntry("base", "")
+	verbose, _ := NewOption([]string{"-v", "--verbose"}, "print more")
+	verbose.SetPersistent(true)
+	persistent.AddOption(verbose)
+	persistent.AddEntry(newEntry("list", "list things", nil))
+	t.Run("global options", entryDefaultUsageTester{
+		iEntry: persistent.children["list"],
+		oUsage: "Usage:\n" +
+			indent + "base list [options]\n" +
+			"\n" +
+			"Global Options:\n" +
+			indent + "-v, --verbose\n" +
+			indent + indent + "print more",
+	}.assertUsage())
+
+	hidden, _ := NewEntry("base", "")
+	hidden.AddEntry(newEntry("list", "list things", nil))
+	hidden.AddEntry(newEntry("debug", "debug things", nil, "<id>"))
+	hidden.children["debug"].SetHidden(true)
+	force, _ := NewOption([]string{"--force"}, "")
+	force.SetHidden(true)
+	hidden.AddOption(force)
+
+	t.Run("hidden", entryDefaultUsageTester{
+		iEntry: hidden,
+		oUsage: "Usage:\n" +
+			indent + "base <command>\n" +
+			"\n" +
+			indent + "To learn more about the available options for each command,\n" +
+			indent + "use the --help flag like so:\n" +
+			"\n" +
+			indent + "base <command> --help\n" +
+			"\n" +
+			"Commands:\n" +
+			indent + "list\n" +
+			indent + indent + "list things",
+	}.assertUsage())
+	t.Run("show hidden", entryDefaultUsageAllTester{
+		iEntry: hidden,
+		oUsage: "Usage:\n" +
+			indent + "base <command> [options] <args>\n" +
+			"\n" +
+			indent + "To learn more about the available options for each command,\n" +
+			indent + "use the --help flag like so:\n" +
+			"\n" +
+			indent + "base <command> --help\n" +
+			"\n" +
+			"Commands:\n" +
+			indent + "debug <id> (hidden)\n" +
+			indent + indent + "debug things\n" +
+			indent + "list\n" +
+			indent + indent + "list things\n" +
+			"\n" +
+			"Options:\n" +
+			indent + "--force (hidden)",
+	}.assertUsage())
+
+	deprecated, _ := NewEntry("base", "")
+	deprecated.AddEntry(newEntry("serve", "", nil))
+	deprecated.AddEntry(newEntry("run", "", nil))
+	deprecated.children["run"].SetDeprecated(&Deprecation{Replacement: "serve"})
+	addr, _ := NewOption([]string{"--addr", "--listen"}, "")
+	addr.DeprecateAlias("--addr", Deprecation{Replacement: "--listen"})
+	deprecated.AddOption(addr)
+	debug, _ := NewOption([]string{"--debug"}, "")
+	debug.SetDeprecated(&Deprecation{RemovalVersion: "v2.0.0"})
+	deprecated.AddOption(debug)
+
+	t.Run("deprecated", entryDefaultUsageTester{
+		iEntry: deprecated,
+		oUsage: "Usage:\n" +
+			indent + "base <command> [options]\n" +
+			"\n" +
+			indent + "To learn more about the available options for each command,\n" +
+			indent + "use the --help flag like so:\n" +
+			"\n" +
+			indent + "base <command> --help\n" +
+			"\n" +
+			"Commands:\n" +
+			indent + "run (deprecated: use serve)\n" +
+			indent + "serve\n" +
+			"\n" +
+			"Options:\n" +
+			indent + "--addr, --listen (--addr deprecated: use --listen)\n" +
+			"\n" +
+			indent + "--debug (deprecated: will be removed in v2.0.0)",
+	}.assertUsage())
+
+	staged, _ := NewEntry("base", "")
+	staged.AddEntry(newEntry("deploy", "", nil))
+	staged.AddEntry(newEntry("preview", "", nil))
+	staged.AddEntry(newEntry("rollout", "", nil))
+	staged.children["preview"].SetStability(Experimental)
+	staged.children["rollout"].SetStability(Beta)
+	canary, _ := NewOption([]string{"--canary"}, "")
+	canary.SetStability(Experimental)
+	staged.AddOption(canary)
+
+	t.Run("stability", entryDefaultUsageTester{
+		iEntry: staged,
+		oUsage: "Usage:\n" +
+			indent + "base <command> [options]\n" +
+			"\n" +
+			indent + "To learn more about the available options for each command,\n" +
+			indent + "use the --help flag like so:\n" +
+			"\n" +
+			indent + "base <command> --help\n" +
+			"\n" +
+			"Commands:\n" +
+			indent + "deploy\n" +
+			indent + "preview (experimental)\n" +
+			indent + "rollout (beta)\n" +
+			"\n" +
+			"Options:\n" +
+			indent + "--canary (experimental)",
+	}.assertUsage())
+
+	environed, _ := NewEntry("serve", "")
+	port, _ := NewOption([]string{"-p", "--port"}, "")
+	port.AddArg("<port>")
+	port.SetEnv("TOOL_PORT", "PORT")
+	environed.AddOption(port)
+	host, _ := NewOption([]string{"--host"}, "")
+	host.SetEnv("TOOL_HOST")
+	environed.AddOption(host)
+	verbose, _ = NewOption([]string{"--verbose"}, "")
+	environed.AddOption(verbose)
+	t.Run("environment", entryDefaultUsageTester{
+		iEntry: environed,
+		oUsage: "Usage:\n" +
+			indent + "serve [options]\n" +
+			"\n" +
+			"Options:\n" +
+			indent + "-p, --port <port> [env: TOOL_PORT, PORT]\n" +
+			"\n" +
+			indent + "--host [env: TOOL_HOST]\n" +
+			"\n" +
+			indent + "--verbose\n" +
+			"\n" +
+			"Environment:\n" +
+			indent + "TOOL_PORT\n" +
+			indent + indent + "Sets -p, --port.\n" +
+			indent + "PORT\n" +
+			indent + indent + "Sets -p, --port.\n" +
+			indent + "TOOL_HOST\n" +
+			indent + indent + "Sets --host.",
+	}.assertUsage())
+
+	exampled, _ := NewEntry("base", "")
+	deploy, _ := NewEntry("deploy", "")
+	exampled.AddEntry(deploy)
+	deploy.AddArg("<env>")
+	deploy.AddExample("Deploy the current branch to staging.", "staging")
+	deploy.AddExample("", "production --config deploy/production.yml --timeout 300 --wait --notify ops@example.com")
+
+	t.Run("examples", entryDefaultUsageTester{
+		iEntry: deploy,
+		oUsage: "Usage:\n" +
+			indent + "base deploy <env>\n" +
+			"\n" +
+			"Examples:\n" +
+			indent + "Deploy the current branch to staging.\n" +
+			indent + indent + "base deploy staging\n" +
+			"\n" +
+			indent + indent + "base deploy production --config deploy/production.yml --timeout \\\n" +
+			indent + indent + indent + "300 --wait --notify ops@example.com",
+	}.assertUsage())
+
+	sectioned, _ := NewEntry("base", "")
+	sectioned.AddSection("Files", "~/.baserc\n/etc/base.conf")
+	sectioned.AddSection("See Also", "git(1), ssh(1)")
+	sectioned.SetFooter("Report bugs at https://example.com/issues.")
+
+	t.Run("sections", entryDefaultUsageTester{
+		iEntry: sectioned,
+		oUsage: "Usage:\n" +
+			indent + "base\n" +
+			"\n" +
+			"Files:\n" +
+			indent + "~/.baserc\n" +
+			indent + "\n" +
+			indent + "/etc/base.conf\n" +
+			"\n" +
+			"See Also:\n" +
+			indent + "git(1), ssh(1)\n" +
+			"\n" +
+			"Report bugs at https://example.com/issues.",
+	}.assertUsage())
+
+	coded, _ := NewEntry("base", "")
+	migrate, _ := NewEntry("migrate", "")
+	coded.AddEntry(migrate)
+	coded.SetExitCode(1, "An unexpected error occurred.")
+	coded.SetExitCode(3, "The operation timed out.")
+	migrate.SetExitCode(3, "The database did not respond in time.")
+
+	t.Run("exit status", entryDefaultUsageTester{
+		iEntry: migrate,
+		oUsage: "Usage:\n" +
+			indent + "base migrate\n" +
+			"\n" +
+			"Exit Status:\n" +
+			indent + "1\n" +
+			indent + indent + "An unexpected error occurred.\n" +
+			indent + "3\n" +
+			indent + indent + "The database did not respond in time.",
+	}.assertUsage())
+
+	categorized, _ := NewEntry("kubectl", "")
+	categorized.SetCategoryOrder("Basic Commands", "Management")
+	for _, spec := range []struct{ name, category string }{
+		{"get", "Basic Commands"},
+		{"logs", "Troubleshooting"},
+		{"create", "Basic Commands"},
+		{"completion", ""},
+		{"cordon", "Management"},
+	} {
+		entry := newEntry(spec.name, "", nil)
+		entry.SetCategory(spec.category)
+		categorized.AddEntry(entry)
+	}
+
+	t.Run("categories", entryDefaultUsageTester{
+		iEntry: categorized,
+		oUsage: "Usage:\n" +
+			indent + "kubectl <command>\n" +
+			"\n" +
+			indent + "To learn more about the available options for each command,\n" +
+			indent + "use the --help flag like so:\n" +
+			"\n" +
+			indent + "kubectl <command> --help\n" +
+			"\n" +
+			"Commands:\n" +
+			indent + "completion\n" +
+			"\n" +
+			"Basic Commands:\n" +
+			indent + "create\n" +
+			indent + "get\n" +
+			"\n" +
+			"Management:\n" +
+			indent + "cordon\n" +
+			"\n" +
+			"Troubleshooting:\n" +
+			indent + "logs",
+	}.assertUsage())
+
+	grouped, _ := NewEntry("serve", "")
+	grouped.AddOptionGroup("TLS")
+	for _, spec := range []struct{ alias, group string }{
+		{"--port", "Networking"},
+		{"--log-level", "Logging"},
+		{"--verbose", ""},
+		{"--cert", "TLS"},
+		{"--host", "Networking"},
+	} {
+		option := newOption(spec.alias)
+		option.SetGroup(spec.group)
+		grouped.AddOption(option)
+	}
+
+	t.Run("option groups", entryDefaultUsageTester{
+		iEntry: grouped,
+		oUsage: "Usage:\n" +
+			indent + "serve [options]\n" +
+			"\n" +
+			"Options:\n" +
+			indent + "--verbose\n" +
+			"\n" +
+			"TLS:\n" +
+			indent + "--cert\n" +
+			"\n" +
+			"Networking:\n" +
+			indent + "--port\n" +
+			"\n" +
+			indent + "--host\n" +
+			"\n" +
+			"Logging:\n" +
+			indent + "--log-level",
+	}.assertUsage())
+
+	t.Run("option constraints", entryDefaultUsageTester{
+		iEntry: constrained,
+		oUsage: "Usage:\n" +
+			indent + "deploy [options] --token <file> (--json | --yaml) [--cert <file> --key]\n" +
+			"\n" +
+			"Options:\n" +
+			indent + "-t, --token <file> (required)\n" +
+			"\n" +
+			indent + "--json (excludes --yaml)\n" +
+			"\n" +
+			indent + "--yaml (excludes --json)\n" +
+			"\n" +
+			indent + "--cert <file> (requires --key)\n" +
+			"\n" +
+			indent + "--key (requires --cert)",
+	}.assertUsage())
 }
 
 func TestEntryUsage(t *testing.T) {
@@ -589,6 +1432,143 @@ func TestEntryLookup(t *testing.T) {
 		iLookup: "foo",
 	}.assertUsage())
 	t.Run("empty name string", entryLookupTester{}.assertUsage())
+	t.Run("unique nested name", entryLookupTester{
+		iLookup: "drop",
+		oUsage:  "base:db:drop",
+	}.assertPathTreeUsage())
+	t.Run("ambiguous nested name", entryLookupTester{
+		iLookup: "create",
+	}.assertPathTreeUsage())
+}
+
+func TestEntrySeal(t *testing.T) {
+	tmpl := template.Must(template.New("").Parse("{{.Name}}"))
+	optionTmpl := template.Must(template.New("").Parse("{{.Aliases}}"))
+	newTree := func(options ...Option) *Entry {
+		root := &Entry{name: "base", tmpl: tmpl, children: make(map[string]*Entry)}
+		child := &Entry{name: "child", tmpl: tmpl, parent: root, options: options}
+		root.children[child.name] = child
+		return root
+	}
+
+	t.Run("baseline", entrySealTester{
+		iEntry: newTree(Option{aliases: []string{"--foo"}, tmpl: optionTmpl}),
+	}.assertSealed())
+	t.Run("duplicate option alias", entrySealTester{
+		iEntry: newTree(
+			Option{aliases: []string{"--foo", "-f"}, tmpl: optionTmpl},
+			Option{aliases: []string{"--bar", "-f"}, tmpl: optionTmpl},
+		),
+		oErr: errors.New("usage: duplicate option alias '-f' in 'base child'"),
+	}.assertInvalidTreeError())
+	t.Run("duplicate persistent option alias", entrySealTester{
+		iEntry: func() *Entry {
+			root := newTree(Option{aliases: []string{"--bar", "-f"}, tmpl: optionTmpl})
+			root.options = []Option{{aliases: []string{"--foo", "-f"}, tmpl: optionTmpl, persistent: true}}
+			return root
+		}(),
+		oErr: errors.New("usage: duplicate option alias '-f' in 'base child'"),
+	}.assertInvalidTreeError())
+	t.Run("non-persistent parent option alias", entrySealTester{
+		iEntry: func() *Entry {
+			root := newTree(Option{aliases: []string{"--bar", "-f"}, tmpl: optionTmpl})
+			root.options = []Option{{aliases: []string{"--foo", "-f"}, tmpl: optionTmpl}}
+			return root
+		}(),
+	}.assertSealed())
+	t.Run("missing option template", entrySealTester{
+		iEntry: newTree(Option{aliases: []string{"--foo"}}),
+		oErr:   errors.New("usage: option '--foo' in 'base child' has no template"),
+	}.assertInvalidTreeError())
+	t.Run("duplicate entry alias", entrySealTester{
+		iEntry: func() *Entry {
+			root := newTree()
+			root.children["child"].aliases = []string{"c"}
+			root.children["other"] = &Entry{name: "other", aliases: []string{"c"}, tmpl: tmpl, parent: root}
+			return root
+		}(),
+		oErr: errors.New("usage: entry name or alias 'c' already in use in 'base'"),
+	}.assertInvalidTreeError())
+	t.Run("missing entry template", entrySealTester{
+		iEntry: &Entry{name: "base"},
+		oErr:   errors.New("usage: entry 'base' has no template"),
+	}.assertInvalidTreeError())
+}
+
+func TestEntryGlobalOptions(t *testing.T) {
+	t.Run("baseline", entryGlobalOptionsTester{
+		iPath:    []string{"user", "create"},
+		oOptions: []string{"-v,--verbose"},
+	}.assertOptions())
+	t.Run("root", entryGlobalOptionsTester{
+		oOptions: []string{},
+	}.assertOptions())
+	t.Run("nearest first", entryGlobalOptionsTester{
+		iPath:    []string{"db", "create"},
+		oOptions: []string{"-f,--force", "-v,--verbose"},
+	}.assertOptions())
+	t.Run("shadowed", entryGlobalOptionsTester{
+		iPath:    []string{"db"},
+		oOptions: []string{},
+	}.assertOptions())
+}
+
+func TestEntryConcurrency(t *testing.T) {
+	t.Run("baseline", entryConcurrencyTester{
+		iWorkers: 1,
+	}.assertTree())
+	t.Run("multiple workers", entryConcurrencyTester{
+		iWorkers: 32,
+	}.assertTree())
+	t.Run("attached while building", entryConcurrencyTester{
+		iWorkers: 16,
+	}.assertSubtree())
+}
+
+func TestEntryFind(t *testing.T) {
+	t.Run("baseline", entryFindTester{
+		iPath:  []string{"db", "create"},
+		oUsage: "base:db:create",
+	}.assertEntry())
+	t.Run("other branch", entryFindTester{
+		iPath:  []string{"user", "create"},
+		oUsage: "base:user:create",
+	}.assertEntry())
+	t.Run("direct child", entryFindTester{
+		iPath:  []string{"db"},
+		oUsage: "base:db",
+	}.assertEntry())
+	t.Run("bare name", entryFindTester{
+		iPath: []string{"drop"},
+		oErr:  errors.New("usage: entry 'drop' not found in 'base'"),
+	}.assertNotFoundError())
+	t.Run("no path", entryFindTester{
+		oUsage: "base",
+	}.assertEntry())
+	t.Run("ambiguous bare name", entryFindTester{
+		iPath: []string{"create"},
+		oErr:  errors.New("usage: entry 'create' not found in 'base'"),
+	}.assertNotFoundError())
+	t.Run("untracked entry", entryFindTester{
+		iPath: []string{"foo"},
+		oErr:  errors.New("usage: entry 'foo' not found in 'base'"),
+	}.assertNotFoundError())
+	t.Run("untracked nested entry", entryFindTester{
+		iPath: []string{"db", "foo"},
+		oErr:  errors.New("usage: entry 'foo' not found in 'base db'"),
+	}.assertNotFoundError())
+	t.Run("aliases", entryFindTester{
+		iPath:  []string{"database", "rm"},
+		oUsage: "base:db:drop",
+	}.assertEntry())
+	t.Run("bare alias", entryFindTester{
+		iPath: []string{"rm"},
+		oErr:  errors.New("usage: entry 'rm' not found in 'base'"),
+	}.assertNotFoundError())
+	t.Run("wrong branch", entryFindTester{
+		iPath: []string{"user", "drop"},
+		oErr:  errors.New("usage: entry 'drop' not found in 'base user'"),
+	}.assertNotFoundError())
 }
 
 func TestNewEntry(t *testing.T) {
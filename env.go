@@ -0,0 +1,94 @@
+package usage
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type EnvVar struct {
+	Name   string
+	Option Option
+}
+
+type ValueSource int
+
+const (
+	SourceDefault ValueSource = iota
+	SourceFlag
+	SourceEnv
+)
+
+func (s ValueSource) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceFlag:
+		return "flag"
+	case SourceEnv:
+		return "env"
+	}
+	return fmt.Sprintf("ValueSource(%d)", int(s))
+}
+
+func (o Option) Env() []string {
+	return o.env
+}
+
+func (o *Option) SetEnv(vars ...string) error {
+	for _, v := range vars {
+		if v == "" {
+			return &UsageError{errors.New("env var name must not be empty")}
+		}
+	}
+	o.env = append([]string(nil), vars...)
+	return nil
+}
+
+func (e *Entry) EnvVars() []EnvVar {
+	defer e.rlock()()
+	output := make([]EnvVar, 0)
+	for _, option := range append(append([]Option(nil), e.options...), e.globalOptions()...) {
+		for _, name := range option.env {
+			output = append(output, EnvVar{Name: name, Option: option})
+		}
+	}
+	return output
+}
+
+func (e *Entry) FillFlags(fs *flag.FlagSet) (map[string]ValueSource, error) {
+	sources := make(map[string]ValueSource)
+	fs.VisitAll(func(f *flag.Flag) {
+		sources[f.Name] = SourceDefault
+	})
+	fs.Visit(func(f *flag.Flag) {
+		sources[f.Name] = SourceFlag
+	})
+	for _, v := range e.EnvVars() {
+		value, ok := os.LookupEnv(v.Name)
+		if !ok || value == "" {
+			continue
+		}
+		names := make([]string, 0, len(v.Option.aliases))
+		set := false
+		for _, alias := range v.Option.aliases {
+			name := strings.TrimLeft(alias, "-")
+			if source, ok := sources[name]; ok {
+				names = append(names, name)
+				set = set || source != SourceDefault
+			}
+		}
+		if set {
+			continue
+		}
+		for _, name := range names {
+			if err := fs.Set(name, value); err != nil {
+				return sources, &UsageError{fmt.Errorf("invalid value '%s' for %s: %w", value, v.Name, err)}
+			}
+			sources[name] = SourceEnv
+		}
+	}
+	return sources, nil
+}
@@ -0,0 +1,191 @@
+package usage
+
+import (
+	"errors"
+	"flag"
+	"testing"
+)
+
+type optionSetEnvTester struct {
+	iVars []string
+	oErr  error
+}
+
+func (tester optionSetEnvTester) assertEnv() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--port"}}
+		gotErr := sampleOption.SetEnv(tester.iVars...)
+		assertNilError(t, gotErr)
+		assertEnvVars(t, sampleOption.Env(), tester.iVars)
+	}
+}
+
+func (tester optionSetEnvTester) assertEmptyEnvStringError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--port"}}
+		got := sampleOption.SetEnv(tester.iVars...)
+		assertError(t, got, tester.oErr)
+		assertEnvVars(t, sampleOption.Env(), []string{})
+	}
+}
+
+type entryEnvVarsTester struct {
+	oVars []string
+}
+
+func (tester entryEnvVarsTester) assertVars() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name: "serve",
+			options: []Option{
+				{aliases: []string{"-p", "--port"}, args: []string{"<port>"}, env: []string{"TOOL_PORT", "PORT"}},
+				{aliases: []string{"--host"}, env: []string{"TOOL_HOST"}},
+				{aliases: []string{"--verbose"}},
+			},
+		}
+		got := make([]string, 0)
+		for _, v := range sampleEntry.EnvVars() {
+			got = append(got, v.Name+"="+v.Option.aliases[0])
+		}
+		assertEnvVars(t, got, tester.oVars)
+	}
+}
+
+type entryFillFlagsTester struct {
+	iArgs    []string
+	iEnv     map[string]string
+	oPort    string
+	oHost    string
+	oSources map[string]ValueSource
+	oErr     error
+}
+
+func (tester entryFillFlagsTester) newFlagSet() (*flag.FlagSet, *string, *string) {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	port := fs.String("port", "80", "")
+	host := fs.String("host", "localhost", "")
+	fs.Bool("verbose", false, "")
+	fs.Int("p", 0, "")
+	fs.Parse(tester.iArgs)
+	return fs, port, host
+}
+
+func (tester entryFillFlagsTester) assertFlags() func(*testing.T) {
+	return func(t *testing.T) {
+		for k, v := range tester.iEnv {
+			t.Setenv(k, v)
+		}
+		sampleEntry := &Entry{
+			name: "serve",
+			options: []Option{
+				{aliases: []string{"-p", "--port"}, args: []string{"<port>"}, env: []string{"TOOL_PORT", "PORT"}},
+				{aliases: []string{"--host"}, env: []string{"TOOL_HOST"}},
+				{aliases: []string{"--verbose"}},
+			},
+		}
+		fs, port, host := tester.newFlagSet()
+		got, gotErr := sampleEntry.FillFlags(fs)
+		assertNilError(t, gotErr)
+		assertFlagValue(t, *port, tester.oPort)
+		assertFlagValue(t, *host, tester.oHost)
+		if len(got) != len(tester.oSources) {
+			t.Fatalf("%d sources returned but wanted %d", len(got), len(tester.oSources))
+		}
+		for name, source := range tester.oSources {
+			if got[name] != source {
+				t.Errorf("source of %q is %s but should be %s", name, got[name], source)
+			}
+		}
+	}
+}
+
+func (tester entryFillFlagsTester) assertInvalidValueError() func(*testing.T) {
+	return func(t *testing.T) {
+		for k, v := range tester.iEnv {
+			t.Setenv(k, v)
+		}
+		sampleEntry := &Entry{
+			name: "serve",
+			options: []Option{
+				{aliases: []string{"-p", "--port"}, args: []string{"<port>"}, env: []string{"TOOL_PORT", "PORT"}},
+				{aliases: []string{"--host"}, env: []string{"TOOL_HOST"}},
+				{aliases: []string{"--verbose"}},
+			},
+		}
+		fs, _, _ := tester.newFlagSet()
+		_, got := sampleEntry.FillFlags(fs)
+		assertEnvError(t, got, tester.oErr)
+	}
+}
+
+func TestOptionSetEnv(t *testing.T) {
+	t.Run("baseline", optionSetEnvTester{
+		iVars: []string{"TOOL_PORT"},
+	}.assertEnv())
+	t.Run("multiple vars", optionSetEnvTester{
+		iVars: []string{"TOOL_PORT", "PORT"},
+	}.assertEnv())
+	t.Run("empty env string", optionSetEnvTester{
+		iVars: []string{"TOOL_PORT", ""},
+		oErr:  errors.New("usage: env var name must not be empty"),
+	}.assertEmptyEnvStringError())
+}
+
+func TestEntryEnvVars(t *testing.T) {
+	t.Run("baseline", entryEnvVarsTester{
+		oVars: []string{"TOOL_PORT=-p", "PORT=-p", "TOOL_HOST=--host"},
+	}.assertVars())
+}
+
+func TestEntryFillFlags(t *testing.T) {
+	t.Run("baseline", entryFillFlagsTester{
+		iEnv:  map[string]string{"TOOL_PORT": "8080", "TOOL_HOST": ""},
+		oPort: "8080",
+		oHost: "localhost",
+		oSources: map[string]ValueSource{
+			"port":    SourceEnv,
+			"p":       SourceEnv,
+			"host":    SourceDefault,
+			"verbose": SourceDefault,
+		},
+	}.assertFlags())
+	t.Run("flag precedence", entryFillFlagsTester{
+		iArgs: []string{"-port", "9090"},
+		iEnv:  map[string]string{"TOOL_PORT": "8080", "TOOL_HOST": "example.com"},
+		oPort: "9090",
+		oHost: "example.com",
+		oSources: map[string]ValueSource{
+			"port":    SourceFlag,
+			"p":       SourceDefault,
+			"host":    SourceEnv,
+			"verbose": SourceDefault,
+		},
+	}.assertFlags())
+	t.Run("alias precedence", entryFillFlagsTester{
+		iArgs: []string{"-p", "9090"},
+		iEnv:  map[string]string{"TOOL_PORT": "8080", "TOOL_HOST": ""},
+		oPort: "80",
+		oHost: "localhost",
+		oSources: map[string]ValueSource{
+			"port":    SourceDefault,
+			"p":       SourceFlag,
+			"host":    SourceDefault,
+			"verbose": SourceDefault,
+		},
+	}.assertFlags())
+	t.Run("fallback var", entryFillFlagsTester{
+		iEnv:  map[string]string{"TOOL_PORT": "", "PORT": "3000", "TOOL_HOST": ""},
+		oPort: "3000",
+		oHost: "localhost",
+		oSources: map[string]ValueSource{
+			"port":    SourceEnv,
+			"p":       SourceEnv,
+			"host":    SourceDefault,
+			"verbose": SourceDefault,
+		},
+	}.assertFlags())
+	t.Run("invalid value", entryFillFlagsTester{
+		iEnv: map[string]string{"TOOL_PORT": "abc"},
+		oErr: errors.New(`usage: invalid value 'abc' for TOOL_PORT: parse error`),
+	}.assertInvalidValueError())
+}
@@ -0,0 +1,80 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Example struct {
+	Description string
+	Command     string
+}
+
+func (e *Entry) Examples() []Example {
+	defer e.rlock()()
+	path := e.path()
+	output := make([]Example, 0, len(e.examples))
+	for _, example := range e.examples {
+		output = append(output, Example{
+			Description: example.Description,
+			Command:     strings.TrimSpace(path + " " + example.Command),
+		})
+	}
+	return output
+}
+
+func (e *Entry) AddExample(description, command string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if strings.TrimSpace(command) == "" {
+		return &UsageError{errors.New("command string must not be empty")}
+	}
+	e.examples = append(e.examples, Example{Description: description, Command: command})
+	return nil
+}
+
+func (e *Entry) CheckExamples() error {
+	type check struct {
+		entry   *Entry
+		path    string
+		example Example
+	}
+	unlock := e.rlock()
+	checks := make([]check, 0)
+	visit(e, func(entry *Entry) {
+		for _, example := range entry.examples {
+			checks = append(checks, check{entry, entry.path(), example})
+		}
+	})
+	unlock()
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].path < checks[j].path
+	})
+	errs := make([]error, 0)
+	for _, c := range checks {
+		if _, err := c.entry.Parse(strings.Fields(c.example.Command)); err != nil {
+			errs = append(errs, &UsageError{fmt.Errorf("invalid example '%s %s': %w", c.path, c.example.Command, errors.Unwrap(err))})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func wrapCommand(command string, length int) []string {
+	lines := make([]string, 0)
+	var b strings.Builder
+	for _, w := range strings.Fields(command) {
+		if b.Len() > 0 && b.Len()+len(w)+1 > length {
+			lines = append(lines, b.String()+" \\")
+			b.Reset()
+		}
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(w)
+	}
+	return append(lines, b.String())
+}
@@ -0,0 +1,147 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entryAddExampleTester struct {
+	iDescription string
+	iCommand     string
+	oExamples    []Example
+	oErr         error
+}
+
+func (tester entryAddExampleTester) assertExamples() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree().children["db"]
+		gotErr := sampleEntry.AddExample(tester.iDescription, tester.iCommand)
+		assertNilError(t, gotErr)
+		assertExamples(t, sampleEntry.Examples(), tester.oExamples)
+	}
+}
+
+func (tester entryAddExampleTester) assertEmptyCommandStringError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree().children["db"]
+		got := sampleEntry.AddExample(tester.iDescription, tester.iCommand)
+		assertEmptyCommandStringError(t, got, tester.oErr)
+		assertExamples(t, sampleEntry.Examples(), []Example{})
+	}
+}
+
+func (tester entryAddExampleTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", tree: newSealedTree()}
+		got := sampleEntry.AddExample(tester.iDescription, tester.iCommand)
+		assertSealedError(t, got, tester.oErr)
+	}
+}
+
+type entryCheckExamplesTester struct {
+	iExamples map[string][]string
+	oErr      error
+}
+
+func (tester entryCheckExamplesTester) assertExamples() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		for path, commands := range tester.iExamples {
+			entry := sampleEntry
+			if path != "" {
+				entry, _ = sampleEntry.Find(path)
+			}
+			for _, command := range commands {
+				entry.AddExample("", command)
+			}
+		}
+		got := sampleEntry.CheckExamples()
+		if tester.oErr == nil {
+			assertNilError(t, got)
+			return
+		}
+		assertExampleError(t, got, tester.oErr)
+	}
+}
+
+type wrapCommandTester struct {
+	iCommand string
+	iLength  int
+	oLines   []string
+}
+
+func (tester wrapCommandTester) assertLines() func(*testing.T) {
+	return func(t *testing.T) {
+		got := wrapCommand(tester.iCommand, tester.iLength)
+		assertLines(t, got, tester.oLines)
+	}
+}
+
+func TestEntryAddExample(t *testing.T) {
+	t.Run("baseline", entryAddExampleTester{
+		iDescription: "Create a database.",
+		iCommand:     "create foo",
+		oExamples:    []Example{{Description: "Create a database.", Command: "base db create foo"}},
+	}.assertExamples())
+	t.Run("no description", entryAddExampleTester{
+		iCommand:  "--force drop foo",
+		oExamples: []Example{{Command: "base db --force drop foo"}},
+	}.assertExamples())
+	t.Run("empty command string", entryAddExampleTester{
+		iDescription: "Create a database.",
+		iCommand:     " ",
+		oErr:         errors.New("usage: command string must not be empty"),
+	}.assertEmptyCommandStringError())
+	t.Run("sealed", entryAddExampleTester{
+		iCommand: "create foo",
+		oErr:     errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryCheckExamples(t *testing.T) {
+	t.Run("baseline", entryCheckExamplesTester{
+		iExamples: map[string][]string{
+			"":   {"db create foo", "--verbose user create"},
+			"db": {"-f drop foo", "rm foo"},
+		},
+	}.assertExamples())
+	t.Run("no examples", entryCheckExamplesTester{}.assertExamples())
+	t.Run("unknown command", entryCheckExamplesTester{
+		iExamples: map[string][]string{
+			"": {"db craete foo"},
+		},
+		oErr: errors.New("usage: invalid example 'base db craete foo': unknown command 'craete' for 'base db'; did you mean 'create'?"),
+	}.assertExamples())
+	t.Run("unknown option", entryCheckExamplesTester{
+		iExamples: map[string][]string{
+			"db": {"--froce drop foo"},
+		},
+		oErr: errors.New("usage: invalid example 'base db --froce drop foo': unknown option '--froce' for 'base db'; did you mean '--force'?"),
+	}.assertExamples())
+	t.Run("multiple", entryCheckExamplesTester{
+		iExamples: map[string][]string{
+			"":   {"db create foo", "usr create"},
+			"db": {"--force=yes create foo"},
+		},
+		oErr: errors.New("usage: invalid example 'base usr create': unknown command 'usr' for 'base'; did you mean 'user'?\n" +
+			"usage: invalid example 'base db --force=yes create foo': option '--force' does not take a value"),
+	}.assertExamples())
+}
+
+func TestWrapCommand(t *testing.T) {
+	t.Run("baseline", wrapCommandTester{
+		iCommand: "base db create foo",
+		iLength:  64,
+		oLines:   []string{"base db create foo"},
+	}.assertLines())
+	t.Run("wrapped", wrapCommandTester{
+		iCommand: "base db create foo --config bar.yml",
+		iLength:  20,
+		oLines:   []string{"base db create foo \\", "--config bar.yml"},
+	}.assertLines())
+	t.Run("long word", wrapCommandTester{
+		iCommand: "base --config some/very/long/path/to/config.yml",
+		iLength:  20,
+		oLines:   []string{"base --config \\", "some/very/long/path/to/config.yml"},
+	}.assertLines())
+}
@@ -0,0 +1,130 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+var osExit = os.Exit
+
+type ExitCode struct {
+	Code    int
+	Meaning string
+	Errors  []error
+}
+
+type ExitCoder interface {
+	ExitCode() int
+}
+
+type runError struct {
+	entry *Entry
+	err   error
+}
+
+func (e runError) Error() string {
+	return e.err.Error()
+}
+
+func (e runError) Unwrap() error {
+	return e.err
+}
+
+func (e *Entry) ExitCodes() []ExitCode {
+	defer e.rlock()()
+	return e.exitCodes()
+}
+
+func (e *Entry) SetExitCode(code int, meaning string, errs ...error) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if code < 0 || code > 255 {
+		return &UsageError{fmt.Errorf("exit code %d out of range", code)}
+	}
+	if meaning == "" {
+		return &UsageError{errors.New("meaning string must not be empty")}
+	}
+	for _, err := range errs {
+		if err == nil {
+			return &UsageError{errors.New("no error provided")}
+		}
+	}
+	codes := make([]ExitCode, 0, len(e.codes)+1)
+	for _, c := range e.codes {
+		if c.Code != code {
+			codes = append(codes, c)
+		}
+	}
+	e.codes = append(codes, ExitCode{Code: code, Meaning: meaning, Errors: append([]error(nil), errs...)})
+	return nil
+}
+
+func (e *Entry) ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	for _, code := range e.ExitCodes() {
+		for _, target := range code.Errors {
+			if errors.Is(err, target) {
+				return code.Code
+			}
+		}
+	}
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return 2
+	}
+	return 1
+}
+
+func (e *Entry) exitCodes() []ExitCode {
+	declared := make(map[int]ExitCode)
+	for ptr := e; ptr != nil; ptr = ptr.parent {
+		for _, code := range ptr.codes {
+			if _, ok := declared[code.Code]; !ok {
+				declared[code.Code] = code
+			}
+		}
+	}
+	output := make([]ExitCode, 0, len(declared))
+	for _, code := range declared {
+		output = append(output, code)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].Code < output[j].Code
+	})
+	return output
+}
+
+func (e *Entry) helpHint() string {
+	defer e.rlock()()
+	help := e.helpOption()
+	if help == nil {
+		return ""
+	}
+	alias := help.aliases[0]
+	for _, a := range help.aliases {
+		if strings.HasPrefix(a, "--") {
+			alias = a
+			break
+		}
+	}
+	return fmt.Sprintf("Run '%s %s' for usage", e.path(), alias)
+}
+
+func resolvedEntry(err error, root *Entry) *Entry {
+	var runErr *runError
+	if errors.As(err, &runErr) {
+		return runErr.entry
+	}
+	return root
+}
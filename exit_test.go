@@ -0,0 +1,394 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errTimeout = errors.New("timed out")
+
+type exitCodeError struct {
+	code int
+}
+
+func (e exitCodeError) Error() string {
+	return fmt.Sprintf("failed with %d", e.code)
+}
+
+func (e exitCodeError) ExitCode() int {
+	return e.code
+}
+
+func stubExit(t *testing.T) *int {
+	t.Helper()
+	code := -1
+	exit := osExit
+	osExit = func(c int) {
+		code = c
+	}
+	t.Cleanup(func() {
+		osExit = exit
+	})
+	return &code
+}
+
+type entrySetExitCodeTester struct {
+	iCode    int
+	iMeaning string
+	iErrs    []error
+	oCodes   []ExitCode
+	oErr     error
+}
+
+func (tester entrySetExitCodeTester) assertExitCodes() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.SetExitCode(1, "An unexpected error occurred.")
+		gotErr := sampleEntry.SetExitCode(tester.iCode, tester.iMeaning, tester.iErrs...)
+		assertNilError(t, gotErr)
+		assertExitCodes(t, sampleEntry.ExitCodes(), tester.oCodes)
+	}
+}
+
+func (tester entrySetExitCodeTester) assertExitCodeError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.SetExitCode(1, "An unexpected error occurred.")
+		got := sampleEntry.SetExitCode(tester.iCode, tester.iMeaning, tester.iErrs...)
+		assertExitCodeError(t, got, tester.oErr)
+		assertExitCodes(t, sampleEntry.ExitCodes(), []ExitCode{{Code: 1, Meaning: "An unexpected error occurred."}})
+	}
+}
+
+func (tester entrySetExitCodeTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", tree: newSealedTree()}
+		got := sampleEntry.SetExitCode(tester.iCode, tester.iMeaning, tester.iErrs...)
+		assertSealedError(t, got, tester.oErr)
+	}
+}
+
+type entryExitCodesTester struct {
+	iPath  []string
+	oCodes []ExitCode
+}
+
+func (tester entryExitCodesTester) assertExitCodes() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.SetExitCode(1, "An unexpected error occurred.")
+		sampleEntry.SetExitCode(3, "The operation timed out.", errTimeout)
+		sampleEntry.children["db"].SetExitCode(3, "The database did not respond in time.", errTimeout)
+		sampleEntry.children["db"].SetExitCode(4, "The database is locked.")
+		entry, _ := sampleEntry.Find(tester.iPath...)
+		if len(tester.iPath) == 0 {
+			entry = sampleEntry
+		}
+		assertExitCodes(t, entry.ExitCodes(), tester.oCodes)
+	}
+}
+
+type entryExitCodeTester struct {
+	iPath []string
+	iErr  error
+	oCode int
+}
+
+func (tester entryExitCodeTester) assertExitCode() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.SetExitCode(1, "An unexpected error occurred.")
+		sampleEntry.SetExitCode(3, "The operation timed out.", errTimeout)
+		sampleEntry.children["db"].SetExitCode(3, "The database did not respond in time.", errTimeout)
+		sampleEntry.children["db"].SetExitCode(4, "The database is locked.")
+		entry, _ := sampleEntry.Find(tester.iPath...)
+		if len(tester.iPath) == 0 {
+			entry = sampleEntry
+		}
+		assertExitCode(t, entry.ExitCode(tester.iErr), tester.oCode)
+	}
+}
+
+type programExitTester struct {
+	iArgs       []string
+	iHandlerErr error
+	oOutput     string
+	oCode       int
+}
+
+func (tester programExitTester) assertExit() func(*testing.T) {
+	return func(t *testing.T) {
+		code := stubExit(t)
+		sampleEntry := newPathTree()
+		sampleEntry.SetExitCode(1, "An unexpected error occurred.")
+		sampleEntry.SetExitCode(3, "The operation timed out.", errTimeout)
+		sampleEntry.children["db"].SetExitCode(3, "The database did not respond in time.", errTimeout)
+		sampleEntry.children["db"].SetExitCode(4, "The database is locked.")
+		sampleProgram := &Program{root: sampleEntry}
+		sampleProgram.EnableHelp(nil)
+		for _, path := range [][]string{{"db", "create"}, {"db", "drop"}, {"user", "create"}} {
+			entry, _ := sampleProgram.Find(path...)
+			entry.SetHandler(func(ctx context.Context, inv *Invocation) error {
+				return tester.iHandlerErr
+			})
+		}
+		var u, b bytes.Buffer
+		sampleProgram.SetOutput(&u)
+		sampleProgram.SetErrorOutput(&b)
+		err := sampleProgram.Run(context.Background(), tester.iArgs)
+		u.Reset()
+		sampleProgram.Exit(err)
+		assertErrorOutput(t, b.String(), tester.oOutput)
+		assertUsage(t, u.String(), "")
+		assertExitCode(t, *code, tester.oCode)
+	}
+}
+
+func (tester programExitTester) assertUninitializedProgramExit() func(*testing.T) {
+	return func(t *testing.T) {
+		code := stubExit(t)
+		sampleProgram := &Program{}
+		sampleProgram.Exit(tester.iHandlerErr)
+		assertExitCode(t, *code, tester.oCode)
+	}
+}
+
+type setExitCodeTester struct {
+	oPanic error
+}
+
+func (tester setExitCodeTester) assertExitCode() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		gotErr := SetExitCode(3, "The operation timed out.", errTimeout)
+		assertNilError(t, gotErr)
+		entry, _ := Find("user", "create")
+		assertExitCode(t, entry.ExitCode(errTimeout), 3)
+		global = nil
+	}
+}
+
+func (tester setExitCodeTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		SetExitCode(3, "The operation timed out.")
+		assertNilProgram(t, global)
+	}
+}
+
+type setErrorOutputTester struct {
+	oErr   error
+	oPanic error
+}
+
+func (tester setErrorOutputTester) assertOutput() func(*testing.T) {
+	return func(t *testing.T) {
+		code := stubExit(t)
+		sampleEntry := newPathTree()
+		sampleEntry.SetExitCode(3, "The operation timed out.", errTimeout)
+		global = &Program{root: sampleEntry}
+		var u, b bytes.Buffer
+		SetOutput(&u)
+		gotErr := SetErrorOutput(&b)
+		assertNilError(t, gotErr)
+		Exit(errTimeout)
+		assertErrorOutput(t, b.String(), "timed out\n")
+		assertUsage(t, u.String(), "")
+		assertExitCode(t, *code, 3)
+		global = nil
+	}
+}
+
+func (tester setErrorOutputTester) assertNoWriterError() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		got := SetErrorOutput(nil)
+		assertError(t, got, tester.oErr)
+		global = nil
+	}
+}
+
+func (tester setErrorOutputTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		SetErrorOutput(&bytes.Buffer{})
+		assertNilProgram(t, global)
+	}
+}
+
+type exitTester struct {
+	oPanic error
+}
+
+func (tester exitTester) assertExit() func(*testing.T) {
+	return func(t *testing.T) {
+		code := stubExit(t)
+		var b bytes.Buffer
+		sampleEntry := newPathTree()
+		sampleEntry.children["db"].SetExitCode(3, "The database did not respond in time.", errTimeout)
+		global = &Program{root: sampleEntry, errOutput: &b}
+		Exit(&runError{global.root.children["db"], errTimeout})
+		assertErrorOutput(t, b.String(), "timed out\n")
+		assertExitCode(t, *code, 3)
+		global = nil
+	}
+}
+
+func (tester exitTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		Exit(nil)
+		assertNilProgram(t, global)
+	}
+}
+
+func TestEntrySetExitCode(t *testing.T) {
+	t.Run("baseline", entrySetExitCodeTester{
+		iCode:    3,
+		iMeaning: "The operation timed out.",
+		iErrs:    []error{errTimeout},
+		oCodes: []ExitCode{
+			{Code: 1, Meaning: "An unexpected error occurred."},
+			{Code: 3, Meaning: "The operation timed out."},
+		},
+	}.assertExitCodes())
+	t.Run("replaced", entrySetExitCodeTester{
+		iCode:    1,
+		iMeaning: "Something went wrong.",
+		oCodes:   []ExitCode{{Code: 1, Meaning: "Something went wrong."}},
+	}.assertExitCodes())
+	t.Run("out of range", entrySetExitCodeTester{
+		iCode:    256,
+		iMeaning: "Too big.",
+		oErr:     errors.New("usage: exit code 256 out of range"),
+	}.assertExitCodeError())
+	t.Run("negative", entrySetExitCodeTester{
+		iCode:    -1,
+		iMeaning: "Too small.",
+		oErr:     errors.New("usage: exit code -1 out of range"),
+	}.assertExitCodeError())
+	t.Run("empty meaning string", entrySetExitCodeTester{
+		iCode: 3,
+		oErr:  errors.New("usage: meaning string must not be empty"),
+	}.assertExitCodeError())
+	t.Run("nil error", entrySetExitCodeTester{
+		iCode:    3,
+		iMeaning: "The operation timed out.",
+		iErrs:    []error{nil},
+		oErr:     errors.New("usage: no error provided"),
+	}.assertExitCodeError())
+	t.Run("sealed", entrySetExitCodeTester{
+		iCode:    3,
+		iMeaning: "The operation timed out.",
+		oErr:     errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryExitCodes(t *testing.T) {
+	t.Run("baseline", entryExitCodesTester{
+		oCodes: []ExitCode{
+			{Code: 1, Meaning: "An unexpected error occurred."},
+			{Code: 3, Meaning: "The operation timed out."},
+		},
+	}.assertExitCodes())
+	t.Run("inherited and overridden", entryExitCodesTester{
+		iPath: []string{"db", "create"},
+		oCodes: []ExitCode{
+			{Code: 1, Meaning: "An unexpected error occurred."},
+			{Code: 3, Meaning: "The database did not respond in time."},
+			{Code: 4, Meaning: "The database is locked."},
+		},
+	}.assertExitCodes())
+	t.Run("sibling", entryExitCodesTester{
+		iPath: []string{"user", "create"},
+		oCodes: []ExitCode{
+			{Code: 1, Meaning: "An unexpected error occurred."},
+			{Code: 3, Meaning: "The operation timed out."},
+		},
+	}.assertExitCodes())
+}
+
+func TestEntryExitCode(t *testing.T) {
+	t.Run("nil", entryExitCodeTester{
+		oCode: 0,
+	}.assertExitCode())
+	t.Run("declared", entryExitCodeTester{
+		iPath: []string{"user", "create"},
+		iErr:  fmt.Errorf("waiting for user: %w", errTimeout),
+		oCode: 3,
+	}.assertExitCode())
+	t.Run("exit coder", entryExitCodeTester{
+		iErr:  fmt.Errorf("deploying: %w", exitCodeError{5}),
+		oCode: 5,
+	}.assertExitCode())
+	t.Run("usage error", entryExitCodeTester{
+		iErr:  &UsageError{errors.New("unknown command 'foo' for 'base'")},
+		oCode: 2,
+	}.assertExitCode())
+	t.Run("other", entryExitCodeTester{
+		iErr:  errors.New("boom"),
+		oCode: 1,
+	}.assertExitCode())
+}
+
+func TestProgramExit(t *testing.T) {
+	t.Run("baseline", programExitTester{
+		iArgs: []string{"db", "create", "foo"},
+		oCode: 0,
+	}.assertExit())
+	t.Run("usage error", programExitTester{
+		iArgs: []string{"db", "craete"},
+		oOutput: "usage: unknown command 'craete' for 'base db'; did you mean 'create'?\n" +
+			"Run 'base db --help' for usage\n",
+		oCode: 2,
+	}.assertExit())
+	t.Run("check error", programExitTester{
+		iArgs: []string{"user", "create", "foo"},
+		oOutput: "usage: unexpected argument 'foo' for 'base user create [options]'\n" +
+			"Run 'base user create --help' for usage\n",
+		oCode: 2,
+	}.assertExit())
+	t.Run("declared", programExitTester{
+		iArgs:       []string{"db", "drop"},
+		iHandlerErr: errTimeout,
+		oOutput:     "timed out\n",
+		oCode:       3,
+	}.assertExit())
+	t.Run("undeclared", programExitTester{
+		iArgs:       []string{"user", "create"},
+		iHandlerErr: errors.New("boom"),
+		oOutput:     "boom\n",
+		oCode:       1,
+	}.assertExit())
+	t.Run("uninitialized", programExitTester{
+		iHandlerErr: errors.New("boom"),
+		oCode:       1,
+	}.assertUninitializedProgramExit())
+}
+
+func TestSetExitCode(t *testing.T) {
+	t.Run("baseline", setExitCodeTester{}.assertExitCode())
+	t.Run("uninitialized", setExitCodeTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
+
+func TestSetErrorOutput(t *testing.T) {
+	t.Run("baseline", setErrorOutputTester{}.assertOutput())
+	t.Run("no writer", setErrorOutputTester{
+		oErr: errors.New("usage: no writer provided"),
+	}.assertNoWriterError())
+	t.Run("uninitialized", setErrorOutputTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
+
+func TestExit(t *testing.T) {
+	t.Run("baseline", exitTester{}.assertExit())
+	t.Run("uninitialized", exitTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
@@ -0,0 +1,70 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+)
+
+type OptionGroup struct {
+	Name    string
+	Options []Option
+}
+
+func (e *Entry) AddOptionGroup(name string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if name == "" {
+		return &UsageError{errors.New("group name must not be empty")}
+	}
+	for _, group := range e.optionGroups {
+		if group == name {
+			return &UsageError{fmt.Errorf("option group '%s' already in use in '%s'", name, e.path())}
+		}
+	}
+	e.optionGroups = append(e.optionGroups, name)
+	return nil
+}
+
+func (e *Entry) OptionGroups() []OptionGroup {
+	defer e.rlock()()
+	names := append(make([]string, 0, len(e.optionGroups)), e.optionGroups...)
+	grouped := make(map[string][]Option)
+	for _, option := range e.options {
+		if option.group == "" {
+			continue
+		}
+		if _, ok := grouped[option.group]; !ok && !contains(names, option.group) {
+			names = append(names, option.group)
+		}
+		grouped[option.group] = append(grouped[option.group], option)
+	}
+	output := make([]OptionGroup, 0, len(names))
+	for _, name := range names {
+		if options, ok := grouped[name]; ok {
+			output = append(output, OptionGroup{Name: name, Options: options})
+		}
+	}
+	return output
+}
+
+func (e *Entry) UngroupedOptions() []Option {
+	defer e.rlock()()
+	output := make([]Option, 0, len(e.options))
+	for _, option := range e.options {
+		if option.group == "" {
+			output = append(output, option)
+		}
+	}
+	return output
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
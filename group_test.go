@@ -0,0 +1,143 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entryAddOptionGroupTester struct {
+	iName   string
+	oGroups []string
+	oErr    error
+}
+
+func (tester entryAddOptionGroupTester) assertGroups() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "serve"}
+		gotErr := sampleEntry.AddOptionGroup(tester.iName)
+		assertNilError(t, gotErr)
+		assertGroups(t, sampleEntry.optionGroups, tester.oGroups)
+	}
+}
+
+func (tester entryAddOptionGroupTester) assertGroupError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "serve", optionGroups: []string{"TLS"}}
+		got := sampleEntry.AddOptionGroup(tester.iName)
+		assertGroupError(t, got, tester.oErr)
+		assertGroups(t, sampleEntry.optionGroups, []string{"TLS"})
+	}
+}
+
+func (tester entryAddOptionGroupTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "serve", tree: newSealedTree()}
+		got := sampleEntry.AddOptionGroup(tester.iName)
+		assertSealedError(t, got, tester.oErr)
+		assertGroups(t, sampleEntry.optionGroups, []string{})
+	}
+}
+
+type entryOptionGroupsTester struct {
+	iDeclared []string
+	oGroups   []string
+	oOptions  [][]string
+}
+
+func (tester entryOptionGroupsTester) assertGroups() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name:         "serve",
+			optionGroups: tester.iDeclared,
+			options: []Option{
+				{aliases: []string{"--port"}, group: "Networking"},
+				{aliases: []string{"--log-level"}, group: "Logging"},
+				{aliases: []string{"--verbose"}},
+				{aliases: []string{"--cert"}, group: "TLS"},
+				{aliases: []string{"--host"}, group: "Networking"},
+			},
+		}
+		got := sampleEntry.OptionGroups()
+		names := make([]string, 0, len(got))
+		for _, group := range got {
+			names = append(names, group.Name)
+		}
+		assertGroups(t, names, tester.oGroups)
+		for i, group := range got {
+			aliases := make([]string, 0)
+			for _, option := range group.Options {
+				aliases = append(aliases, option.aliases...)
+			}
+			assertAliases(t, aliases, tester.oOptions[i])
+		}
+	}
+}
+
+type entryUngroupedOptionsTester struct {
+	oOptions []string
+}
+
+func (tester entryUngroupedOptionsTester) assertOptions() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name: "serve",
+			options: []Option{
+				{aliases: []string{"--port"}, group: "Networking"},
+				{aliases: []string{"--verbose"}},
+				{aliases: []string{"--cert"}, group: "TLS"},
+				{aliases: []string{"-q", "--quiet"}},
+			},
+		}
+		got := make([]string, 0)
+		for _, option := range sampleEntry.UngroupedOptions() {
+			got = append(got, option.aliases...)
+		}
+		assertAliases(t, got, tester.oOptions)
+	}
+}
+
+func TestEntryAddOptionGroup(t *testing.T) {
+	t.Run("baseline", entryAddOptionGroupTester{
+		iName:   "Networking",
+		oGroups: []string{"Networking"},
+	}.assertGroups())
+	t.Run("empty name string", entryAddOptionGroupTester{
+		oErr: errors.New("usage: group name must not be empty"),
+	}.assertGroupError())
+	t.Run("duplicate group", entryAddOptionGroupTester{
+		iName: "TLS",
+		oErr:  errors.New("usage: option group 'TLS' already in use in 'serve'"),
+	}.assertGroupError())
+	t.Run("sealed", entryAddOptionGroupTester{
+		iName: "TLS",
+		oErr:  errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryOptionGroups(t *testing.T) {
+	t.Run("baseline", entryOptionGroupsTester{
+		iDeclared: []string{"TLS", "Networking", "Logging"},
+		oGroups:   []string{"TLS", "Networking", "Logging"},
+		oOptions:  [][]string{{"--cert"}, {"--port", "--host"}, {"--log-level"}},
+	}.assertGroups())
+	t.Run("undeclared groups", entryOptionGroupsTester{
+		oGroups:  []string{"Networking", "Logging", "TLS"},
+		oOptions: [][]string{{"--port", "--host"}, {"--log-level"}, {"--cert"}},
+	}.assertGroups())
+	t.Run("partially declared groups", entryOptionGroupsTester{
+		iDeclared: []string{"TLS"},
+		oGroups:   []string{"TLS", "Networking", "Logging"},
+		oOptions:  [][]string{{"--cert"}, {"--port", "--host"}, {"--log-level"}},
+	}.assertGroups())
+	t.Run("empty declared group", entryOptionGroupsTester{
+		iDeclared: []string{"Debugging", "Logging"},
+		oGroups:   []string{"Logging", "Networking", "TLS"},
+		oOptions:  [][]string{{"--log-level"}, {"--port", "--host"}, {"--cert"}},
+	}.assertGroups())
+}
+
+func TestEntryUngroupedOptions(t *testing.T) {
+	t.Run("baseline", entryUngroupedOptionsTester{
+		oOptions: []string{"--verbose", "-q", "--quiet"},
+	}.assertOptions())
+}
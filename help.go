@@ -0,0 +1,96 @@
+package usage
+
+import (
+	"errors"
+)
+
+func (e *Entry) EnableHelp(option *Option) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if option == nil {
+		option, _ = NewOption([]string{"-h", "--help"}, "Show help for the command.")
+	}
+	if len(option.aliases) == 0 {
+		return &UsageError{errors.New("option must have at least one alias")}
+	}
+	for _, alias := range option.aliases {
+		if len(alias) == 0 {
+			return &UsageError{errors.New("alias string must not be empty")}
+		}
+	}
+	if e.helpEntry == nil && len(e.args) == 0 {
+		entry, _ := NewEntry("help", "Show help for a command.")
+		entry.args = append(entry.args, "[command...]")
+		if err := e.checkConflicts(entry, entry.names()); err != nil {
+			return err
+		}
+		e.addChild(entry)
+		e.helpEntry = entry
+	}
+	o := *option
+	e.help = &o
+	return nil
+}
+
+func (e *Entry) HelpOption() *Option {
+	defer e.rlock()()
+	help := e.helpOption()
+	if help == nil {
+		return nil
+	}
+	o := *help
+	return &o
+}
+
+func (e *Entry) helpOption() *Option {
+	for ptr := e; ptr != nil; ptr = ptr.parent {
+		if ptr.help != nil {
+			return ptr.help
+		}
+	}
+	return nil
+}
+
+func (e *Entry) isHelpEntry() bool {
+	return e.parent != nil && e.parent.helpEntry == e
+}
+
+func (e *Entry) isHelpAlias(alias string) bool {
+	help := e.helpOption()
+	return help != nil && e.option(alias) == help
+}
+
+func (e *Entry) onlyBuiltinChildren() bool {
+	for _, child := range e.children {
+		if child != e.helpEntry && (e.version == nil || child != e.version.entry) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Entry) dropBuiltinChildren() {
+	if e.helpEntry != nil {
+		delete(e.children, e.helpEntry.name)
+		e.helpEntry = nil
+	}
+	if e.version != nil && e.version.entry != nil {
+		delete(e.children, e.version.entry.name)
+		e.version.entry = nil
+	}
+}
+
+func (e *Entry) ownsAlias(option Option) bool {
+	for _, alias := range option.aliases {
+		for i := range e.options {
+			for _, a := range e.options[i].aliases {
+				if a == alias {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type entryEnableHelpTester struct {
+	iOption  *Option
+	iArgs    []string
+	oAliases []string
+	oCommand bool
+	oSummary string
+	oErr     error
+}
+
+func (tester entryEnableHelpTester) assertHelp() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.children = make(map[string]*Entry)
+		sampleEntry.args = tester.iArgs
+		gotErr := sampleEntry.EnableHelp(tester.iOption)
+		assertNilError(t, gotErr)
+		assertAliases(t, sampleEntry.HelpOption().aliases, tester.oAliases)
+		_, gotCommand := sampleEntry.children["help"]
+		if gotCommand != tester.oCommand {
+			t.Errorf("help command added is %t but should be %t", gotCommand, tester.oCommand)
+		}
+	}
+}
+
+func (tester entryEnableHelpTester) assertArgsAdded() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry, _ := NewEntry("base", "")
+		assertNilError(t, sampleEntry.EnableHelp(tester.iOption))
+		for _, arg := range tester.iArgs {
+			assertNilError(t, sampleEntry.AddArg(arg))
+		}
+		assertArgs(t, sampleEntry.Args(), tester.iArgs)
+		assertAliases(t, sampleEntry.HelpOption().aliases, tester.oAliases)
+		_, gotCommand := sampleEntry.children["help"]
+		if gotCommand != tester.oCommand {
+			t.Errorf("help command kept is %t but should be %t", gotCommand, tester.oCommand)
+		}
+		result, _ := sampleEntry.Parse([]string{"-h"})
+		if !result.Help {
+			t.Error("help option was not recognized")
+		}
+	}
+}
+
+func (tester entryEnableHelpTester) assertInherited() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		gotErr := sampleEntry.EnableHelp(tester.iOption)
+		assertNilError(t, gotErr)
+		child, _ := sampleEntry.Find("db", "create")
+		assertAliases(t, child.HelpOption().aliases, tester.oAliases)
+		assertOption(t, child.option(tester.oAliases[0]), sampleEntry.help)
+	}
+}
+
+func (tester entryEnableHelpTester) assertSummary() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry, _ := NewEntry("base", "")
+		list, _ := NewEntry("list", "")
+		for _, arg := range tester.iArgs {
+			list.AddArg(arg)
+		}
+		sampleEntry.AddEntry(list)
+		assertNilError(t, sampleEntry.EnableHelp(tester.iOption))
+		assertNilError(t, sampleEntry.EnableVersion(VersionCommand, VersionInfo{Version: "v1.0.0"}))
+		got := deriveSummaryString(*sampleEntry.snapshot(false))
+		assertSummary(t, got, tester.oSummary)
+	}
+}
+
+func (tester entryEnableHelpTester) assertConflictError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.children["db"].SetAliases([]string{"help"})
+		got := sampleEntry.EnableHelp(tester.iOption)
+		assertConflictError(t, got, tester.oErr)
+		if sampleEntry.HelpOption() != nil {
+			t.Error("help option was set after conflict")
+		}
+	}
+}
+
+func (tester entryEnableHelpTester) assertNoAliasesError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		got := sampleEntry.EnableHelp(tester.iOption)
+		assertNoAliasesError(t, got, tester.oErr)
+	}
+}
+
+func (tester entryEnableHelpTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", children: make(map[string]*Entry), tree: newSealedTree()}
+		got := sampleEntry.EnableHelp(tester.iOption)
+		assertSealedError(t, got, tester.oErr)
+		assertNilOption(t, sampleEntry.HelpOption())
+	}
+}
+
+type entryParseHelpTester struct {
+	iArgs []string
+	oPath []string
+	oHelp bool
+	oErr  error
+}
+
+func (tester entryParseHelpTester) assertHelp() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.EnableHelp(nil)
+		got, gotErr := sampleEntry.Parse(tester.iArgs)
+		assertNilError(t, gotErr)
+		assertAncestry(t, got.Path, tester.oPath)
+		if got.Help != tester.oHelp {
+			t.Errorf("help is %t but should be %t", got.Help, tester.oHelp)
+		}
+	}
+}
+
+func (tester entryParseHelpTester) assertNotFoundError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.EnableHelp(nil)
+		_, got := sampleEntry.Parse(tester.iArgs)
+		assertNotFoundError(t, got, tester.oErr)
+	}
+}
+
+type enableHelpTester struct {
+	iArgs  []string
+	oUsage string
+	oPanic error
+}
+
+func (tester enableHelpTester) assertUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		var b strings.Builder
+		SetOutput(&b)
+		gotErr := EnableHelp(nil)
+		assertNilError(t, gotErr)
+		assertNilError(t, Run(context.Background(), tester.iArgs))
+		assertUsage(t, b.String(), tester.oUsage)
+		global = nil
+	}
+}
+
+func (tester enableHelpTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		EnableHelp(nil)
+		assertNilProgram(t, global)
+	}
+}
+
+func TestEntryEnableHelp(t *testing.T) {
+	t.Run("baseline", entryEnableHelpTester{
+		oAliases: []string{"-h", "--help"},
+		oCommand: true,
+	}.assertHelp())
+	t.Run("custom option", entryEnableHelpTester{
+		iOption:  &Option{aliases: []string{"-?", "--usage"}},
+		oAliases: []string{"-?", "--usage"},
+		oCommand: true,
+	}.assertHelp())
+	t.Run("args present", entryEnableHelpTester{
+		iArgs:    []string{"<foo>"},
+		oAliases: []string{"-h", "--help"},
+	}.assertHelp())
+	t.Run("args added after", entryEnableHelpTester{
+		iArgs:    []string{"<foo>", "[bar]"},
+		oAliases: []string{"-h", "--help"},
+	}.assertArgsAdded())
+	t.Run("summary", entryEnableHelpTester{
+		oSummary: "base <command> [options]",
+	}.assertSummary())
+	t.Run("summary with args", entryEnableHelpTester{
+		iArgs:    []string{"<name>"},
+		oSummary: "base <command> [options] <args>",
+	}.assertSummary())
+	t.Run("inherited", entryEnableHelpTester{
+		oAliases: []string{"-h", "--help"},
+	}.assertInherited())
+	t.Run("conflicting command", entryEnableHelpTester{
+		oErr: errors.New("usage: entry name or alias 'help' already in use in 'base'"),
+	}.assertConflictError())
+	t.Run("no aliases", entryEnableHelpTester{
+		iOption: &Option{},
+		oErr:    errors.New("usage: option must have at least one alias"),
+	}.assertNoAliasesError())
+	t.Run("sealed", entryEnableHelpTester{
+		oErr: errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryParseHelp(t *testing.T) {
+	t.Run("baseline", entryParseHelpTester{
+		iArgs: []string{"db", "--help"},
+		oPath: []string{"base", "db"},
+		oHelp: true,
+	}.assertHelp())
+	t.Run("short option", entryParseHelpTester{
+		iArgs: []string{"db", "create", "-h"},
+		oPath: []string{"base", "db", "create"},
+		oHelp: true,
+	}.assertHelp())
+	t.Run("bundled option", entryParseHelpTester{
+		iArgs: []string{"-vh"},
+		oPath: []string{"base"},
+		oHelp: true,
+	}.assertHelp())
+	t.Run("no help", entryParseHelpTester{
+		iArgs: []string{"db", "create", "foo"},
+		oPath: []string{"base", "db", "create"},
+	}.assertHelp())
+	t.Run("help command", entryParseHelpTester{
+		iArgs: []string{"help", "db", "rm"},
+		oPath: []string{"base", "db", "drop"},
+		oHelp: true,
+	}.assertHelp())
+	t.Run("help command with bare name", entryParseHelpTester{
+		iArgs: []string{"help", "drop"},
+		oPath: []string{"base", "db", "drop"},
+		oHelp: true,
+	}.assertHelp())
+	t.Run("help command without path", entryParseHelpTester{
+		iArgs: []string{"help"},
+		oPath: []string{"base"},
+		oHelp: true,
+	}.assertHelp())
+	t.Run("help command with unknown path", entryParseHelpTester{
+		iArgs: []string{"help", "db", "foo"},
+		oErr:  errors.New("usage: entry 'foo' not found in 'base db'"),
+	}.assertNotFoundError())
+}
+
+func TestEnableHelp(t *testing.T) {
+	t.Run("baseline", enableHelpTester{
+		iArgs:  []string{"user", "--help"},
+		oUsage: "base:user\n",
+	}.assertUsage())
+	t.Run("help command", enableHelpTester{
+		iArgs:  []string{"help", "user", "create"},
+		oUsage: "base:user:create\n",
+	}.assertUsage())
+	t.Run("uninitialized", enableHelpTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
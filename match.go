@@ -0,0 +1,94 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Matching int
+
+const (
+	PrefixMatch Matching = 1 << iota
+	IgnoreCase
+)
+
+const ExactMatch Matching = 0
+
+func (e *Entry) Matching() Matching {
+	defer e.rlock()()
+	return e.matchMode()
+}
+
+func (e *Entry) SetMatching(matching Matching) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if matching&^(PrefixMatch|IgnoreCase) != 0 {
+		return &UsageError{fmt.Errorf("unknown matching mode %d", matching)}
+	}
+	e.matching = &matching
+	return nil
+}
+
+func (e *Entry) matchMode() Matching {
+	for ptr := e; ptr != nil; ptr = ptr.parent {
+		if ptr.matching != nil {
+			return *ptr.matching
+		}
+	}
+	return ExactMatch
+}
+
+func (e *Entry) match(token string) (*Entry, error) {
+	if child := e.child(token); child != nil {
+		return child, nil
+	}
+	mode := e.matchMode()
+	if mode == ExactMatch {
+		return nil, nil
+	}
+	fold := func(s string) string {
+		if mode&IgnoreCase != 0 {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+	var exact, prefixed []*Entry
+	for _, child := range e.children {
+		if child.gated() {
+			continue
+		}
+		isExact, isPrefixed := false, false
+		for _, name := range child.names() {
+			isExact = isExact || fold(name) == fold(token)
+			isPrefixed = isPrefixed || strings.HasPrefix(fold(name), fold(token))
+		}
+		if isExact {
+			exact = append(exact, child)
+		}
+		if isPrefixed && mode&PrefixMatch != 0 {
+			prefixed = append(prefixed, child)
+		}
+	}
+	for _, candidates := range [][]*Entry{exact, prefixed} {
+		switch {
+		case len(candidates) == 1:
+			return candidates[0], nil
+		case len(candidates) > 1:
+			return nil, ambiguousError(token, candidates)
+		}
+	}
+	return nil, nil
+}
+
+func ambiguousError(token string, matches []*Entry) error {
+	paths := make([]string, 0, len(matches))
+	for _, match := range matches {
+		paths = append(paths, "'"+match.path()+"'")
+	}
+	sort.Strings(paths)
+	return &UsageError{fmt.Errorf("entry '%s' is ambiguous: %s", token, strings.Join(paths, ", "))}
+}
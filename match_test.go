@@ -0,0 +1,219 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entryMatchingTester struct {
+	iMatching Matching
+	oMatching Matching
+}
+
+func (tester entryMatchingTester) assertMatching() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base", children: make(map[string]*Entry), matching: &tester.iMatching}
+		deploy := &Entry{name: "deploy", children: make(map[string]*Entry), parent: sampleEntry}
+		sampleEntry.children["deploy"] = deploy
+		deploy.children["status"] = &Entry{name: "status", parent: deploy}
+		got := deploy.children["status"].Matching()
+		assertMatching(t, got, tester.oMatching)
+	}
+}
+
+type entrySetMatchingTester struct {
+	iMatching Matching
+	oErr      error
+}
+
+func (tester entrySetMatchingTester) assertMatching() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base"}
+		gotErr := sampleEntry.SetMatching(tester.iMatching)
+		assertNilError(t, gotErr)
+		assertMatching(t, *sampleEntry.matching, tester.iMatching)
+	}
+}
+
+func (tester entrySetMatchingTester) assertUnknownMatchingError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base"}
+		got := sampleEntry.SetMatching(tester.iMatching)
+		assertMatchingError(t, got, tester.oErr)
+	}
+}
+
+func (tester entrySetMatchingTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base", tree: newSealedTree()}
+		got := sampleEntry.SetMatching(tester.iMatching)
+		assertSealedError(t, got, tester.oErr)
+	}
+}
+
+type entryMatchTester struct {
+	iMatching Matching
+	iOverride *Matching
+	iPath     []string
+	oPath     string
+	oErr      error
+}
+
+func (tester entryMatchTester) newTree() *Entry {
+	root := &Entry{name: "base", children: make(map[string]*Entry), matching: &tester.iMatching}
+	for _, name := range []string{"deploy", "delete", "list"} {
+		root.children[name] = &Entry{name: name, children: make(map[string]*Entry), parent: root}
+	}
+	root.children["list"].aliases = []string{"ls"}
+	deploy := root.children["deploy"]
+	deploy.matching = tester.iOverride
+	for _, name := range []string{"status", "start", "stop"} {
+		deploy.children[name] = &Entry{name: name, children: make(map[string]*Entry), parent: deploy}
+	}
+	return root
+}
+
+func (tester entryMatchTester) assertEntry() func(*testing.T) {
+	return func(t *testing.T) {
+		got, gotErr := tester.newTree().Find(tester.iPath...)
+		assertNilError(t, gotErr)
+		assertPath(t, got.path(), tester.oPath)
+	}
+}
+
+func (tester entryMatchTester) assertNotFoundError() func(*testing.T) {
+	return func(t *testing.T) {
+		gotEntry, got := tester.newTree().Find(tester.iPath...)
+		assertNilEntry(t, gotEntry)
+		assertNotFoundError(t, got, tester.oErr)
+	}
+}
+
+func (tester entryMatchTester) assertAmbiguousError() func(*testing.T) {
+	return func(t *testing.T) {
+		gotEntry, got := tester.newTree().Find(tester.iPath...)
+		assertNilEntry(t, gotEntry)
+		assertAmbiguousError(t, got, tester.oErr)
+	}
+}
+
+func (tester entryMatchTester) assertResolvedEntry() func(*testing.T) {
+	return func(t *testing.T) {
+		got, _, gotErr := tester.newTree().Resolve(tester.iPath)
+		assertNilError(t, gotErr)
+		assertPath(t, got.path(), tester.oPath)
+	}
+}
+
+func (tester entryMatchTester) assertResolvedAmbiguousError() func(*testing.T) {
+	return func(t *testing.T) {
+		gotEntry, _, got := tester.newTree().Resolve(tester.iPath)
+		assertAmbiguousError(t, got, tester.oErr)
+		assertPath(t, gotEntry.path(), tester.oPath)
+	}
+}
+
+func TestEntryMatching(t *testing.T) {
+	t.Run("baseline", entryMatchingTester{
+		iMatching: PrefixMatch,
+		oMatching: PrefixMatch,
+	}.assertMatching())
+	t.Run("combined", entryMatchingTester{
+		iMatching: PrefixMatch | IgnoreCase,
+		oMatching: PrefixMatch | IgnoreCase,
+	}.assertMatching())
+	t.Run("exact", entryMatchingTester{}.assertMatching())
+}
+
+func TestEntrySetMatching(t *testing.T) {
+	t.Run("baseline", entrySetMatchingTester{
+		iMatching: PrefixMatch,
+	}.assertMatching())
+	t.Run("exact", entrySetMatchingTester{
+		iMatching: ExactMatch,
+	}.assertMatching())
+	t.Run("unknown matching mode", entrySetMatchingTester{
+		iMatching: 16,
+		oErr:      errors.New("usage: unknown matching mode 16"),
+	}.assertUnknownMatchingError())
+	t.Run("sealed", entrySetMatchingTester{
+		iMatching: PrefixMatch,
+		oErr:      errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryMatch(t *testing.T) {
+	exact := ExactMatch
+
+	t.Run("baseline", entryMatchTester{
+		iMatching: PrefixMatch,
+		iPath:     []string{"dep", "stat"},
+		oPath:     "base deploy status",
+	}.assertEntry())
+	t.Run("full names", entryMatchTester{
+		iMatching: PrefixMatch,
+		iPath:     []string{"deploy", "status"},
+		oPath:     "base deploy status",
+	}.assertEntry())
+	t.Run("alias prefix", entryMatchTester{
+		iMatching: PrefixMatch,
+		iPath:     []string{"l"},
+		oPath:     "base list",
+	}.assertEntry())
+	t.Run("ambiguous prefix", entryMatchTester{
+		iMatching: PrefixMatch,
+		iPath:     []string{"de"},
+		oErr:      errors.New("usage: entry 'de' is ambiguous: 'base delete', 'base deploy'"),
+	}.assertAmbiguousError())
+	t.Run("ambiguous nested prefix", entryMatchTester{
+		iMatching: PrefixMatch,
+		iPath:     []string{"dep", "st"},
+		oErr:      errors.New("usage: entry 'st' is ambiguous: 'base deploy start', 'base deploy status', 'base deploy stop'"),
+	}.assertAmbiguousError())
+	t.Run("case sensitive prefix", entryMatchTester{
+		iMatching: PrefixMatch,
+		iPath:     []string{"DEP", "stat"},
+		oErr:      errors.New("usage: entry 'DEP' not found in 'base'"),
+	}.assertNotFoundError())
+	t.Run("ignore case", entryMatchTester{
+		iMatching: IgnoreCase,
+		iPath:     []string{"DePloy", "STATUS"},
+		oPath:     "base deploy status",
+	}.assertEntry())
+	t.Run("ignore case without prefix", entryMatchTester{
+		iMatching: IgnoreCase,
+		iPath:     []string{"DEP", "status"},
+		oErr:      errors.New("usage: entry 'DEP' not found in 'base'"),
+	}.assertNotFoundError())
+	t.Run("ignore case prefix", entryMatchTester{
+		iMatching: PrefixMatch | IgnoreCase,
+		iPath:     []string{"DEP", "Stat"},
+		oPath:     "base deploy status",
+	}.assertEntry())
+	t.Run("exact", entryMatchTester{
+		iPath: []string{"dep", "status"},
+		oErr:  errors.New("usage: entry 'dep' not found in 'base'"),
+	}.assertNotFoundError())
+	t.Run("overridden", entryMatchTester{
+		iMatching: PrefixMatch,
+		iOverride: &exact,
+		iPath:     []string{"dep", "stat"},
+		oErr:      errors.New("usage: entry 'stat' not found in 'base deploy'"),
+	}.assertNotFoundError())
+	t.Run("resolve", entryMatchTester{
+		iMatching: PrefixMatch,
+		iPath:     []string{"dep", "stat", "foo"},
+		oPath:     "base deploy status",
+	}.assertResolvedEntry())
+	t.Run("resolve leaf args", entryMatchTester{
+		iMatching: PrefixMatch,
+		iPath:     []string{"dep", "sto", "st"},
+		oPath:     "base deploy stop",
+	}.assertResolvedEntry())
+	t.Run("resolve ambiguous prefix", entryMatchTester{
+		iMatching: PrefixMatch,
+		iPath:     []string{"dep", "st"},
+		oPath:     "base deploy",
+		oErr:      errors.New("usage: entry 'st' is ambiguous: 'base deploy start', 'base deploy status', 'base deploy stop'"),
+	}.assertResolvedAmbiguousError())
+}
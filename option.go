@@ -11,10 +11,18 @@ import (
 var defaultOptionTmpl string
 
 type Option struct {
-	Description string
-	tmpl        *template.Template
-	aliases     []string
-	args        []string
+	Description       string
+	tmpl              *template.Template
+	aliases           []string
+	args              []string
+	notes             []string
+	persistent        bool
+	group             string
+	hidden            bool
+	deprecation       *Deprecation
+	aliasDeprecations []aliasDeprecation
+	stability         Stability
+	env               []string
 }
 
 func (o Option) Args() []string {
@@ -25,6 +33,34 @@ func (o Option) Aliases() []string {
 	return o.aliases
 }
 
+func (o Option) Notes() []string {
+	return o.notes
+}
+
+func (o Option) Persistent() bool {
+	return o.persistent
+}
+
+func (o *Option) SetPersistent(persistent bool) {
+	o.persistent = persistent
+}
+
+func (o Option) Group() string {
+	return o.group
+}
+
+func (o *Option) SetGroup(group string) {
+	o.group = group
+}
+
+func (o Option) Hidden() bool {
+	return o.hidden
+}
+
+func (o *Option) SetHidden(hidden bool) {
+	o.hidden = hidden
+}
+
 func (o *Option) AddArg(arg string) error {
 	if arg == "" {
 		return &UsageError{errors.New("arg string must not be empty")}
@@ -52,6 +88,15 @@ func (o Option) Usage() string {
 	return b.String()
 }
 
+func (o Option) hasAlias(alias string) bool {
+	for _, a := range o.aliases {
+		if a == alias {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *Option) setTemplate(tmpl *template.Template) {
 	o.tmpl = tmpl
 }
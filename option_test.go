@@ -262,3 +262,64 @@ func TestNewOption(t *testing.T) {
 		oErr:         errors.New("usage: alias string must not be empty"),
 	}.assertEmptyAliasStringError())
 }
+
+type optionSetPersistentTester struct {
+	iPersistent bool
+}
+
+func (tester optionSetPersistentTester) assertPersistent() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--foo"}}
+		sampleOption.SetPersistent(tester.iPersistent)
+		if got := sampleOption.Persistent(); got != tester.iPersistent {
+			t.Errorf("persistent is %t but should be %t", got, tester.iPersistent)
+		}
+	}
+}
+
+func TestOptionSetPersistent(t *testing.T) {
+	t.Run("baseline", optionSetPersistentTester{
+		iPersistent: true,
+	}.assertPersistent())
+	t.Run("not persistent", optionSetPersistentTester{}.assertPersistent())
+}
+
+type optionSetGroupTester struct {
+	iGroup string
+}
+
+func (tester optionSetGroupTester) assertGroup() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--foo"}}
+		sampleOption.SetGroup(tester.iGroup)
+		assertGroup(t, sampleOption.Group(), tester.iGroup)
+	}
+}
+
+func TestOptionSetGroup(t *testing.T) {
+	t.Run("baseline", optionSetGroupTester{
+		iGroup: "Networking",
+	}.assertGroup())
+	t.Run("ungrouped", optionSetGroupTester{}.assertGroup())
+}
+
+type optionSetHiddenTester struct {
+	iHidden bool
+}
+
+func (tester optionSetHiddenTester) assertHidden() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--foo"}}
+		sampleOption.SetHidden(tester.iHidden)
+		if got := sampleOption.Hidden(); got != tester.iHidden {
+			t.Errorf("hidden is %t but should be %t", got, tester.iHidden)
+		}
+	}
+}
+
+func TestOptionSetHidden(t *testing.T) {
+	t.Run("baseline", optionSetHiddenTester{
+		iHidden: true,
+	}.assertHidden())
+	t.Run("visible", optionSetHiddenTester{}.assertHidden())
+}
@@ -0,0 +1,96 @@
+package usage
+
+import (
+	"errors"
+	"sort"
+)
+
+type EntryLess func(a, b Entry) bool
+
+type OptionLess func(a, b Option) bool
+
+func EntriesByName(a, b Entry) bool {
+	return a.name < b.name
+}
+
+func EntriesByInsertion(a, b Entry) bool {
+	return a.seq < b.seq
+}
+
+func OptionsByAlias(a, b Option) bool {
+	return a.aliases[0] < b.aliases[0]
+}
+
+func OptionsByInsertion(a, b Option) bool {
+	return false
+}
+
+func (e *Entry) SetEntryOrder(less EntryLess) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	e.entryOrder = less
+	return nil
+}
+
+func (e *Entry) SetOptionOrder(less OptionLess) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	e.optionOrder = less
+	return nil
+}
+
+func (e *Entry) entryLess() EntryLess {
+	for ptr := e; ptr != nil; ptr = ptr.parent {
+		if ptr.entryOrder != nil {
+			return ptr.entryOrder
+		}
+	}
+	return EntriesByName
+}
+
+func (e *Entry) optionLess() OptionLess {
+	for ptr := e; ptr != nil; ptr = ptr.parent {
+		if ptr.optionOrder != nil {
+			return ptr.optionOrder
+		}
+	}
+	return OptionsByInsertion
+}
+
+func (e *Entry) sortEntries(entries []Entry) {
+	less := e.entryLess()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seq < entries[j].seq
+	})
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		a.tree, b.tree = nil, nil
+		return less(a, b)
+	})
+}
+
+func (e *Entry) sortOptions(options []Option) {
+	less := e.optionLess()
+	sort.SliceStable(options, func(i, j int) bool {
+		return less(options[i], options[j])
+	})
+}
+
+func (e *Entry) addChild(entry *Entry) {
+	if e.tree != nil && entry.tree != nil && !e.sameTree(entry) {
+		entry.tree.resolve().merged.Store(e.tree.resolve())
+	}
+	visit(entry, func(c *Entry) {
+		if e.tree == nil || c.tree == nil {
+			c.tree = e.tree
+		}
+	})
+	e.nextSeq++
+	entry.seq = e.nextSeq
+	entry.parent = e
+	e.children[entry.name] = entry
+}
@@ -0,0 +1,175 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entryOrderTester struct {
+	iEntryOrder  EntryLess
+	iOptionOrder OptionLess
+	iChildOrder  OptionLess
+	oEntries     []string
+	oOptions     []string
+	oChild       []string
+	oErr         error
+}
+
+func (tester entryOrderTester) assertOrder() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry, _ := NewEntry("base", "")
+		for _, name := range []string{"status", "deploy", "rollback"} {
+			entry, _ := NewEntry(name, "")
+			for _, alias := range []string{"--zone", "--app", "--force"} {
+				option, _ := NewOption([]string{alias}, "")
+				entry.AddOption(option)
+			}
+			sampleEntry.AddEntry(entry)
+		}
+		for _, alias := range []string{"--verbose", "--config"} {
+			option, _ := NewOption([]string{alias}, "")
+			sampleEntry.AddOption(option)
+		}
+		assertNilError(t, sampleEntry.SetEntryOrder(tester.iEntryOrder))
+		assertNilError(t, sampleEntry.SetOptionOrder(tester.iOptionOrder))
+		assertNilError(t, sampleEntry.children["deploy"].SetOptionOrder(tester.iChildOrder))
+		gotEntries := make([]string, 0)
+		for _, entry := range sampleEntry.Entries() {
+			gotEntries = append(gotEntries, entry.name)
+		}
+		assertEntryNames(t, gotEntries, tester.oEntries)
+		gotOptions := make([]string, 0)
+		for _, option := range sampleEntry.Options() {
+			gotOptions = append(gotOptions, option.aliases...)
+		}
+		assertAliases(t, gotOptions, tester.oOptions)
+		gotChild := make([]string, 0)
+		for _, option := range sampleEntry.children["deploy"].snapshot(false).UngroupedOptions() {
+			gotChild = append(gotChild, option.aliases...)
+		}
+		assertAliases(t, gotChild, tester.oChild)
+	}
+}
+
+func (tester entryOrderTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base", tree: newSealedTree()}
+		assertSealedError(t, sampleEntry.SetEntryOrder(tester.iEntryOrder), tester.oErr)
+		assertSealedError(t, sampleEntry.SetOptionOrder(tester.iOptionOrder), tester.oErr)
+	}
+}
+
+func (tester entryOrderTester) assertInsertionOrder() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry, _ := NewEntry("base", "")
+		sampleEntry.SetEntryOrder(EntriesByInsertion)
+		for _, name := range []string{"zeta", "alpha", "mid"} {
+			entry, _ := NewEntry(name, "")
+			sampleEntry.AddEntry(entry)
+		}
+		for _, name := range []string{"one", "two", "three"} {
+			entry, _ := NewEntry(name, "")
+			sampleEntry.children["zeta"].AddEntry(entry)
+		}
+		sampleEntry.children["alpha"].EnableHelp(nil)
+		sampleEntry.children["mid"].EnableVersion(VersionCommand, VersionInfo{Version: "v1.0.0"})
+		got := make([]string, 0)
+		for _, entry := range sampleEntry.Entries() {
+			got = append(got, entry.name)
+		}
+		assertEntryNames(t, got, tester.oEntries)
+		got = make([]string, 0)
+		for _, entry := range sampleEntry.children["zeta"].Entries() {
+			got = append(got, entry.name)
+		}
+		assertEntryNames(t, got, tester.oChild)
+	}
+}
+
+type setOrderTester struct {
+	oEntries []string
+	oPanic   error
+}
+
+func (tester setOrderTester) assertOrder() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry, _ := NewEntry("base", "")
+		for _, name := range []string{"status", "deploy", "rollback"} {
+			entry, _ := NewEntry(name, "")
+			sampleEntry.AddEntry(entry)
+		}
+		global = &Program{root: sampleEntry}
+		assertNilError(t, SetEntryOrder(EntriesByInsertion))
+		assertNilError(t, SetOptionOrder(OptionsByAlias))
+		got := make([]string, 0)
+		for _, entry := range Entries() {
+			got = append(got, entry.name)
+		}
+		assertEntryNames(t, got, tester.oEntries)
+		global = nil
+	}
+}
+
+func (tester setOrderTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		SetEntryOrder(EntriesByName)
+		assertNilProgram(t, global)
+	}
+}
+
+func TestEntryOrder(t *testing.T) {
+	t.Run("baseline", entryOrderTester{
+		oEntries: []string{"deploy", "rollback", "status"},
+		oOptions: []string{"--verbose", "--config"},
+		oChild:   []string{"--zone", "--app", "--force"},
+	}.assertOrder())
+	t.Run("insertion", entryOrderTester{
+		iEntryOrder:  EntriesByInsertion,
+		iOptionOrder: OptionsByInsertion,
+		oEntries:     []string{"status", "deploy", "rollback"},
+		oOptions:     []string{"--verbose", "--config"},
+		oChild:       []string{"--zone", "--app", "--force"},
+	}.assertOrder())
+	t.Run("alphabetical", entryOrderTester{
+		iEntryOrder:  EntriesByName,
+		iOptionOrder: OptionsByAlias,
+		oEntries:     []string{"deploy", "rollback", "status"},
+		oOptions:     []string{"--config", "--verbose"},
+		oChild:       []string{"--app", "--force", "--zone"},
+	}.assertOrder())
+	t.Run("overridden by child", entryOrderTester{
+		iOptionOrder: OptionsByAlias,
+		iChildOrder:  OptionsByInsertion,
+		oEntries:     []string{"deploy", "rollback", "status"},
+		oOptions:     []string{"--config", "--verbose"},
+		oChild:       []string{"--zone", "--app", "--force"},
+	}.assertOrder())
+	t.Run("custom", entryOrderTester{
+		iEntryOrder: func(a, b Entry) bool {
+			return a.Name() == "status" && b.Name() != "status"
+		},
+		iOptionOrder: func(a, b Option) bool {
+			return len(a.Aliases()[0]) < len(b.Aliases()[0])
+		},
+		oEntries: []string{"status", "deploy", "rollback"},
+		oOptions: []string{"--config", "--verbose"},
+		oChild:   []string{"--app", "--zone", "--force"},
+	}.assertOrder())
+	t.Run("grandchildren", entryOrderTester{
+		oEntries: []string{"zeta", "alpha", "mid"},
+		oChild:   []string{"one", "two", "three"},
+	}.assertInsertionOrder())
+	t.Run("sealed", entryOrderTester{
+		oErr: errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestSetOrder(t *testing.T) {
+	t.Run("baseline", setOrderTester{
+		oEntries: []string{"status", "deploy", "rollback"},
+	}.assertOrder())
+	t.Run("uninitialized", setOrderTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
@@ -0,0 +1,168 @@
+package usage
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Result struct {
+	Entry   *Entry
+	Path    []string
+	Options []ParsedOption
+	Args    []string
+	Help    bool
+	Version bool
+}
+
+type ParsedOption struct {
+	Option Option
+	Alias  string
+	Values []string
+}
+
+func (r *Result) Has(alias string) bool {
+	_, ok := r.lookup(alias)
+	return ok
+}
+
+func (r *Result) Values(alias string) []string {
+	parsed, _ := r.lookup(alias)
+	return parsed.Values
+}
+
+func (r *Result) lookup(alias string) (ParsedOption, bool) {
+	for i := len(r.Options) - 1; i >= 0; i-- {
+		for _, a := range r.Options[i].Option.aliases {
+			if a == alias {
+				return r.Options[i], true
+			}
+		}
+	}
+	return ParsedOption{}, false
+}
+
+func (e *Entry) Parse(args []string) (*Result, error) {
+	defer e.rlock()()
+	result := &Result{
+		Entry:   e,
+		Path:    []string{e.name},
+		Options: make([]ParsedOption, 0),
+		Args:    make([]string, 0),
+	}
+	for i := 0; i < len(args); i++ {
+		token := args[i]
+		if token == "--" {
+			result.Args = append(result.Args, args[i+1:]...)
+			break
+		}
+		if isOptionToken(token) {
+			parsed, consumed, err := result.Entry.parseOption(token, args[i+1:])
+			if err != nil {
+				return result, err
+			}
+			for _, p := range parsed {
+				result.Help = result.Help || result.Entry.isHelpAlias(p.Alias)
+				result.Version = result.Version || result.Entry.isVersionAlias(p.Alias)
+			}
+			result.Options = append(result.Options, parsed...)
+			i += consumed
+			continue
+		}
+		if len(result.Entry.children) == 0 {
+			result.Args = append(result.Args, token)
+			continue
+		}
+		child, err := result.Entry.match(token)
+		if err != nil {
+			return result, err
+		}
+		if child == nil {
+			ptr := result.Entry
+			return result, unknownError("command", token, ptr.path(), suggest(token, ptr.commandNames()))
+		}
+		result.Entry = child
+		result.Path = append(result.Path, child.name)
+	}
+	if result.Entry.isVersionEntry() {
+		result.Version = true
+	}
+	if result.Entry.isHelpEntry() {
+		target, err := result.Entry.parent.find(result.Args)
+		if err != nil && len(result.Args) == 1 {
+			target, err = result.Entry.parent.search(result.Args[0])
+		}
+		if err != nil {
+			return result, err
+		}
+		result.Entry = target
+		result.Path = make([]string, 0, len(result.Path))
+		for ptr := target; ptr != e.parent; ptr = ptr.parent {
+			result.Path = append([]string{ptr.name}, result.Path...)
+		}
+		result.Args = make([]string, 0)
+		result.Help = true
+	}
+	return result, nil
+}
+
+func (e *Entry) parseOption(token string, next []string) ([]ParsedOption, int, error) {
+	alias, value, hasValue := strings.Cut(token, "=")
+	option := e.option(alias)
+	if option == nil && !strings.HasPrefix(token, "--") && len(token) > 2 {
+		return e.parseBundle(token, next)
+	}
+	if option == nil {
+		return nil, 0, unknownError("option", alias, e.path(), suggest(alias, e.optionAliases()))
+	}
+	values := make([]string, 0, len(option.args))
+	if hasValue {
+		if len(option.args) == 0 {
+			return nil, 0, &UsageError{fmt.Errorf("option '%s' does not take a value", alias)}
+		}
+		values = append(values, value)
+	}
+	consumed, err := takeValues(option, alias, &values, next)
+	if err != nil {
+		return nil, 0, err
+	}
+	return []ParsedOption{{Option: *option, Alias: alias, Values: values}}, consumed, nil
+}
+
+func (e *Entry) parseBundle(token string, next []string) ([]ParsedOption, int, error) {
+	parsed := make([]ParsedOption, 0)
+	flags := []rune(token[1:])
+	for j, flag := range flags {
+		alias := "-" + string(flag)
+		option := e.option(alias)
+		if option == nil {
+			return nil, 0, unknownError("option", alias, e.path(), suggest(alias, e.optionAliases()))
+		}
+		values := make([]string, 0, len(option.args))
+		if len(option.args) == 0 {
+			parsed = append(parsed, ParsedOption{Option: *option, Alias: alias, Values: values})
+			continue
+		}
+		if attached := string(flags[j+1:]); attached != "" {
+			values = append(values, attached)
+		}
+		consumed, err := takeValues(option, alias, &values, next)
+		if err != nil {
+			return nil, 0, err
+		}
+		parsed = append(parsed, ParsedOption{Option: *option, Alias: alias, Values: values})
+		return parsed, consumed, nil
+	}
+	return parsed, 0, nil
+}
+
+func takeValues(option *Option, alias string, values *[]string, next []string) (int, error) {
+	consumed := 0
+	for len(*values) < len(option.args) {
+		if consumed >= len(next) {
+			return 0, &UsageError{fmt.Errorf("missing argument %s for option '%s'", option.args[len(*values)], alias)}
+		}
+		*values = append(*values, next[consumed])
+		consumed++
+	}
+	return consumed, nil
+}
@@ -0,0 +1,284 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type resultHasTester struct {
+	iAlias string
+	oHas   bool
+}
+
+func (tester resultHasTester) assertHas() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleResult := &Result{Options: []ParsedOption{{
+			Option: Option{aliases: []string{"-v", "--verbose"}},
+			Alias:  "-v",
+		}}}
+		got := sampleResult.Has(tester.iAlias)
+		if got != tester.oHas {
+			t.Errorf("has is %t but should be %t", got, tester.oHas)
+		}
+	}
+}
+
+type resultValuesTester struct {
+	iAlias  string
+	oValues []string
+}
+
+func (tester resultValuesTester) assertValues() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleResult := &Result{Options: []ParsedOption{
+			{
+				Option: Option{aliases: []string{"-o", "--output"}},
+				Alias:  "-o",
+				Values: []string{"foo"},
+			},
+			{
+				Option: Option{aliases: []string{"-o", "--output"}},
+				Alias:  "--output",
+				Values: []string{"bar"},
+			},
+		}}
+		got := sampleResult.Values(tester.iAlias)
+		assertValues(t, got, tester.oValues)
+	}
+}
+
+type entryParseTester struct {
+	iArgs    []string
+	oPath    []string
+	oOptions []ParsedOption
+	oArgs    []string
+	oErr     error
+}
+
+func (tester entryParseTester) assertResult() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		got, gotErr := sampleEntry.Parse(tester.iArgs)
+		assertNilError(t, gotErr)
+		assertAncestry(t, got.Path, tester.oPath)
+		assertName(t, got.Entry.name, tester.oPath[len(tester.oPath)-1])
+		assertParsedOptions(t, got.Options, tester.oOptions)
+		assertArgs(t, got.Args, tester.oArgs)
+	}
+}
+
+func (tester entryParseTester) assertParseError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		gotResult, got := sampleEntry.Parse(tester.iArgs)
+		assertParseError(t, got, tester.oErr)
+		assertAncestry(t, gotResult.Path, tester.oPath)
+	}
+}
+
+type parseTester struct {
+	iArgs  []string
+	oPath  []string
+	oPanic error
+}
+
+func (tester parseTester) assertResult() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		got, gotErr := Parse(tester.iArgs)
+		assertNilError(t, gotErr)
+		assertAncestry(t, got.Path, tester.oPath)
+		global = nil
+	}
+}
+
+func (tester parseTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		Parse(tester.iArgs)
+		assertNilProgram(t, global)
+	}
+}
+
+func TestResultHas(t *testing.T) {
+	t.Run("baseline", resultHasTester{
+		iAlias: "-v",
+		oHas:   true,
+	}.assertHas())
+	t.Run("other alias", resultHasTester{
+		iAlias: "--verbose",
+		oHas:   true,
+	}.assertHas())
+	t.Run("missing", resultHasTester{
+		iAlias: "--force",
+	}.assertHas())
+}
+
+func TestResultValues(t *testing.T) {
+	t.Run("baseline", resultValuesTester{
+		iAlias:  "-o",
+		oValues: []string{"bar"},
+	}.assertValues())
+	t.Run("missing", resultValuesTester{
+		iAlias: "--force",
+	}.assertValues())
+}
+
+func TestEntryParse(t *testing.T) {
+	t.Run("baseline", entryParseTester{
+		iArgs:    []string{"db", "create", "foo"},
+		oPath:    []string{"base", "db", "create"},
+		oOptions: []ParsedOption{},
+		oArgs:    []string{"foo"},
+	}.assertResult())
+	t.Run("no args", entryParseTester{
+		iArgs:    []string{},
+		oPath:    []string{"base"},
+		oOptions: []ParsedOption{},
+		oArgs:    []string{},
+	}.assertResult())
+	t.Run("options", entryParseTester{
+		iArgs: []string{"--verbose", "db", "-f", "create", "foo"},
+		oPath: []string{"base", "db", "create"},
+		oOptions: []ParsedOption{
+			{Alias: "--verbose", Values: []string{}},
+			{Alias: "-f", Values: []string{}},
+		},
+		oArgs: []string{"foo"},
+	}.assertResult())
+	t.Run("separate value", entryParseTester{
+		iArgs:    []string{"--config", "foo.yml", "user"},
+		oPath:    []string{"base", "user"},
+		oOptions: []ParsedOption{{Alias: "--config", Values: []string{"foo.yml"}}},
+		oArgs:    []string{},
+	}.assertResult())
+	t.Run("inline value", entryParseTester{
+		iArgs:    []string{"--config=foo.yml", "user"},
+		oPath:    []string{"base", "user"},
+		oOptions: []ParsedOption{{Alias: "--config", Values: []string{"foo.yml"}}},
+		oArgs:    []string{},
+	}.assertResult())
+	t.Run("bundled options", entryParseTester{
+		iArgs: []string{"-vo", "out.txt", "user"},
+		oPath: []string{"base", "user"},
+		oOptions: []ParsedOption{
+			{Alias: "-v", Values: []string{}},
+			{Alias: "-o", Values: []string{"out.txt"}},
+		},
+		oArgs: []string{},
+	}.assertResult())
+	t.Run("bundled attached value", entryParseTester{
+		iArgs: []string{"-voout.txt"},
+		oPath: []string{"base"},
+		oOptions: []ParsedOption{
+			{Alias: "-v", Values: []string{}},
+			{Alias: "-o", Values: []string{"out.txt"}},
+		},
+		oArgs: []string{},
+	}.assertResult())
+	t.Run("terminator", entryParseTester{
+		iArgs:    []string{"database", "create", "--", "--force", "foo"},
+		oPath:    []string{"base", "db", "create"},
+		oOptions: []ParsedOption{},
+		oArgs:    []string{"--force", "foo"},
+	}.assertResult())
+	t.Run("unknown option", entryParseTester{
+		iArgs: []string{"--verbsoe"},
+		oPath: []string{"base"},
+		oErr:  errors.New("usage: unknown option '--verbsoe' for 'base'; did you mean '--verbose'?"),
+	}.assertParseError())
+	t.Run("unknown bundled option", entryParseTester{
+		iArgs: []string{"db", "-fx"},
+		oPath: []string{"base", "db"},
+		oErr:  errors.New("usage: unknown option '-x' for 'base db'; did you mean '-f'?"),
+	}.assertParseError())
+	t.Run("option of wrong level", entryParseTester{
+		iArgs: []string{"db", "create", "--force"},
+		oPath: []string{"base", "db", "create"},
+		oErr:  errors.New("usage: unknown option '--force' for 'base db create'"),
+	}.assertParseError())
+	t.Run("parent option after command", entryParseTester{
+		iArgs: []string{"user", "--config"},
+		oPath: []string{"base", "user"},
+		oErr:  errors.New("usage: unknown option '--config' for 'base user'"),
+	}.assertParseError())
+	t.Run("missing value", entryParseTester{
+		iArgs: []string{"--config"},
+		oPath: []string{"base"},
+		oErr:  errors.New("usage: missing argument <file> for option '--config'"),
+	}.assertParseError())
+	t.Run("missing bundled value", entryParseTester{
+		iArgs: []string{"-vo"},
+		oPath: []string{"base"},
+		oErr:  errors.New("usage: missing argument <file> for option '-o'"),
+	}.assertParseError())
+	t.Run("unexpected value", entryParseTester{
+		iArgs: []string{"--verbose=true"},
+		oPath: []string{"base"},
+		oErr:  errors.New("usage: option '--verbose' does not take a value"),
+	}.assertParseError())
+	t.Run("unknown command", entryParseTester{
+		iArgs: []string{"db", "crate"},
+		oPath: []string{"base", "db"},
+		oErr:  errors.New("usage: unknown command 'crate' for 'base db'; did you mean 'create'?"),
+	}.assertParseError())
+}
+
+type entryParsePersistentTester struct {
+	iArgs    []string
+	oOptions []ParsedOption
+	oErr     error
+}
+
+func (tester entryParsePersistentTester) newEntry() *Entry {
+	sampleEntry := newPathTree()
+	sampleEntry.options[0].SetPersistent(true)
+	return sampleEntry
+}
+
+func (tester entryParsePersistentTester) assertOptions() func(*testing.T) {
+	return func(t *testing.T) {
+		got, gotErr := tester.newEntry().Parse(tester.iArgs)
+		assertNilError(t, gotErr)
+		assertParsedOptions(t, got.Options, tester.oOptions)
+	}
+}
+
+func (tester entryParsePersistentTester) assertParseError() func(*testing.T) {
+	return func(t *testing.T) {
+		_, got := tester.newEntry().Parse(tester.iArgs)
+		assertParseError(t, got, tester.oErr)
+	}
+}
+
+func TestEntryParsePersistent(t *testing.T) {
+	t.Run("baseline", entryParsePersistentTester{
+		iArgs:    []string{"db", "create", "--verbose", "foo"},
+		oOptions: []ParsedOption{{Alias: "--verbose", Values: []string{}}},
+	}.assertOptions())
+	t.Run("bundled", entryParsePersistentTester{
+		iArgs: []string{"db", "-fv"},
+		oOptions: []ParsedOption{
+			{Alias: "-f", Values: []string{}},
+			{Alias: "-v", Values: []string{}},
+		},
+	}.assertOptions())
+	t.Run("not persistent", entryParsePersistentTester{
+		iArgs: []string{"user", "--config", "foo.yml"},
+		oErr:  errors.New("usage: unknown option '--config' for 'base user'"),
+	}.assertParseError())
+	t.Run("suggestion", entryParsePersistentTester{
+		iArgs: []string{"user", "--verbos"},
+		oErr:  errors.New("usage: unknown option '--verbos' for 'base user'; did you mean '--verbose'?"),
+	}.assertParseError())
+}
+
+func TestParse(t *testing.T) {
+	t.Run("baseline", parseTester{
+		iArgs: []string{"user", "create"},
+		oPath: []string{"base", "user", "create"},
+	}.assertResult())
+	t.Run("uninitialized", parseTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
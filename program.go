@@ -0,0 +1,415 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+type Program struct {
+	mu         sync.RWMutex
+	root       *Entry
+	entryTmpl  *template.Template
+	optionTmpl *template.Template
+	output     io.Writer
+	warnings   io.Writer
+	errOutput  io.Writer
+}
+
+func (p *Program) Root() (*Entry, error) {
+	if err := p.checkInit(); err != nil {
+		return nil, err
+	}
+	return p.root, nil
+}
+
+func (p *Program) Args() ([]string, error) {
+	if err := p.checkInit(); err != nil {
+		return nil, err
+	}
+	return p.root.Args(), nil
+}
+
+func (p *Program) Options() ([]Option, error) {
+	if err := p.checkInit(); err != nil {
+		return nil, err
+	}
+	return p.root.Options(), nil
+}
+
+func (p *Program) Entries() ([]Entry, error) {
+	if err := p.checkInit(); err != nil {
+		return nil, err
+	}
+	return p.root.Entries(), nil
+}
+
+func (p *Program) AddArg(arg string) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	return p.root.AddArg(arg)
+}
+
+func (p *Program) AddOption(option *Option) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	unlock := p.root.rlock()
+	tmpl := p.optionTmpl
+	unlock()
+	if option != nil && tmpl != nil {
+		o := *option
+		o.setTemplate(tmpl)
+		option = &o
+	}
+	return p.root.AddOption(option)
+}
+
+func (p *Program) AddEntry(entry *Entry) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	if err := p.root.AddEntry(entry); err != nil {
+		return err
+	}
+	defer p.root.lock()()
+	p.applyTemplates(entry)
+	return nil
+}
+
+func (p *Program) SetName(name string) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	return p.root.SetName(name)
+}
+
+func (p *Program) Usage() (string, error) {
+	if err := p.checkInit(); err != nil {
+		return "", err
+	}
+	return p.root.Usage(), nil
+}
+
+func (p *Program) UsageAll() (string, error) {
+	if err := p.checkInit(); err != nil {
+		return "", err
+	}
+	return p.root.UsageAll(), nil
+}
+
+func (p *Program) Lookup(lookup string) (string, error) {
+	if err := p.checkInit(); err != nil {
+		return "", err
+	}
+	return p.root.Lookup(lookup), nil
+}
+
+func (p *Program) LookupPath(path ...string) (string, error) {
+	entry, err := p.Find(path...)
+	if err != nil {
+		return "", err
+	}
+	return entry.Usage(), nil
+}
+
+func (p *Program) Find(path ...string) (*Entry, error) {
+	if err := p.checkInit(); err != nil {
+		return nil, err
+	}
+	return p.root.Find(path...)
+}
+
+func (p *Program) Resolve(args []string) (*Entry, []string, error) {
+	if err := p.checkInit(); err != nil {
+		return nil, nil, err
+	}
+	return p.root.Resolve(args)
+}
+
+func (p *Program) SetMatching(matching Matching) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	return p.root.SetMatching(matching)
+}
+
+func (p *Program) Parse(args []string) (*Result, error) {
+	if err := p.checkInit(); err != nil {
+		return nil, err
+	}
+	return p.root.Parse(args)
+}
+
+func (p *Program) EnableHelp(option *Option) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	unlock := p.root.rlock()
+	tmpl := p.optionTmpl
+	unlock()
+	if option != nil && tmpl != nil {
+		o := *option
+		o.setTemplate(tmpl)
+		option = &o
+	}
+	if err := p.root.EnableHelp(option); err != nil {
+		return err
+	}
+	defer p.root.lock()()
+	p.applyTemplates(p.root)
+	return nil
+}
+
+func (p *Program) EnableVersion(mode VersionMode, overrides VersionInfo) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	if err := p.root.EnableVersion(mode, overrides); err != nil {
+		return err
+	}
+	defer p.root.lock()()
+	p.applyTemplates(p.root)
+	return nil
+}
+
+func (p *Program) SetEntryOrder(less EntryLess) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	return p.root.SetEntryOrder(less)
+}
+
+func (p *Program) SetOptionOrder(less OptionLess) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	return p.root.SetOptionOrder(less)
+}
+
+func (p *Program) SetGate(gate *Gate) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	return p.root.SetGate(gate)
+}
+
+func (p *Program) SetFooter(footer string) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	return p.root.SetFooter(footer)
+}
+
+func (p *Program) SetExitCode(code int, meaning string, errs ...error) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	return p.root.SetExitCode(code, meaning, errs...)
+}
+
+func (p *Program) SetOutput(w io.Writer) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	if w == nil {
+		return &UsageError{errors.New("no writer provided")}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.output = w
+	return nil
+}
+
+func (p *Program) SetWarningOutput(w io.Writer) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	if w == nil {
+		return &UsageError{errors.New("no writer provided")}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.warnings = w
+	return nil
+}
+
+func (p *Program) SetErrorOutput(w io.Writer) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	if w == nil {
+		return &UsageError{errors.New("no writer provided")}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.errOutput = w
+	return nil
+}
+
+func (p *Program) Run(ctx context.Context, args []string) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	result, err := p.root.Parse(args)
+	if err != nil {
+		p.printUsage(result.Entry)
+		return &runError{result.Entry, err}
+	}
+	if result.Help {
+		fmt.Fprintln(p.writer(os.Stdout), result.Entry.Usage())
+		return nil
+	}
+	if result.Version {
+		fmt.Fprintln(p.writer(os.Stdout), result.Entry.Version())
+		return nil
+	}
+	if err := result.Entry.CheckArgs(result.Args); err != nil {
+		p.printUsage(result.Entry)
+		return &runError{result.Entry, err}
+	}
+	passed := make([]string, 0, len(result.Options))
+	for _, option := range result.Options {
+		passed = append(passed, option.Alias)
+	}
+	if err := result.Entry.CheckOptions(passed); err != nil {
+		p.printUsage(result.Entry)
+		return &runError{result.Entry, err}
+	}
+	handler := result.Entry.Handler()
+	if handler == nil {
+		p.printUsage(result.Entry)
+		return &runError{result.Entry, noHandlerError(strings.Join(result.Path, " "))}
+	}
+	p.printWarnings(result.Warnings())
+	if err := handler(ctx, &Invocation{Result: result}); err != nil {
+		return &runError{result.Entry, err}
+	}
+	return nil
+}
+
+func (p *Program) Exit(err error) {
+	if err == nil {
+		osExit(0)
+		return
+	}
+	if p.checkInit() != nil {
+		fmt.Fprintln(os.Stderr, err)
+		osExit(1)
+		return
+	}
+	entry := resolvedEntry(err, p.root)
+	p.mu.RLock()
+	w := p.errOutput
+	p.mu.RUnlock()
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintln(w, err)
+	var usageErr *UsageError
+	if hint := entry.helpHint(); hint != "" && errors.As(err, &usageErr) {
+		fmt.Fprintln(w, hint)
+	}
+	osExit(entry.ExitCode(err))
+}
+
+func (p *Program) SetEntryTemplate(tmpl *template.Template) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	defer p.root.lock()()
+	if p.root.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	p.entryTmpl = tmpl
+	visit(p.root, func(e *Entry) {
+		e.setTemplate(tmpl)
+	})
+	return nil
+}
+
+func (p *Program) SetOptionTemplate(tmpl *template.Template) error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	defer p.root.lock()()
+	if p.root.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	p.optionTmpl = tmpl
+	visit(p.root, func(e *Entry) {
+		for i := range e.options {
+			e.options[i].setTemplate(tmpl)
+		}
+	})
+	return nil
+}
+
+func (p *Program) Seal() error {
+	if err := p.checkInit(); err != nil {
+		return err
+	}
+	return p.root.Seal()
+}
+
+func (p *Program) printUsage(entry *Entry) {
+	fmt.Fprintln(p.writer(os.Stderr), entry.Usage())
+}
+
+func (p *Program) printWarnings(warnings []string) {
+	p.mu.RLock()
+	w := p.warnings
+	p.mu.RUnlock()
+	if w == nil {
+		w = os.Stderr
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(w, "warning: "+warning)
+	}
+}
+
+func (p *Program) writer(fallback io.Writer) io.Writer {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.output == nil {
+		return fallback
+	}
+	return p.output
+}
+
+func (p *Program) applyTemplates(entry *Entry) {
+	visit(entry, func(e *Entry) {
+		if p.entryTmpl != nil {
+			e.setTemplate(p.entryTmpl)
+		}
+		if p.optionTmpl != nil {
+			for i := range e.options {
+				e.options[i].setTemplate(p.optionTmpl)
+			}
+			if e.help != nil {
+				e.help.setTemplate(p.optionTmpl)
+			}
+		}
+	})
+}
+
+func (p *Program) checkInit() error {
+	if p == nil || p.root == nil {
+		return &UsageError{errors.New("program not initialized")}
+	}
+	return nil
+}
+
+func NewProgram(name string) (*Program, error) {
+	root, err := NewEntry(name, "")
+	if err != nil {
+		return nil, err
+	}
+	return &Program{root: root}, nil
+}
@@ -0,0 +1,1310 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+)
+
+type programRootTester struct {
+	oErr error
+}
+
+func (tester programRootTester) assertRoot() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		root := &Entry{name: "foo"}
+		sampleProgram := &Program{root: root}
+		got, gotErr := sampleProgram.Root()
+		assertNilError(t, gotErr)
+		assertEntry(t, got, root)
+	}
+}
+
+func (tester programRootTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		gotEntry, got := sampleProgram.Root()
+		assertNilEntry(t, gotEntry)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programArgsTester struct {
+	oArgs []string
+	oErr  error
+}
+
+func (tester programArgsTester) assertArgs() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: &Entry{args: tester.oArgs}}
+		got, gotErr := sampleProgram.Args()
+		assertNilError(t, gotErr)
+		assertArgs(t, got, tester.oArgs)
+	}
+}
+
+func (tester programArgsTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		_, got := sampleProgram.Args()
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programOptionsTester struct {
+	oOptions []Option
+	oErr     error
+}
+
+func (tester programOptionsTester) assertOptions() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: &Entry{options: tester.oOptions}}
+		got, gotErr := sampleProgram.Options()
+		assertNilError(t, gotErr)
+		assertOptions(t, got, tester.oOptions)
+	}
+}
+
+func (tester programOptionsTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		_, got := sampleProgram.Options()
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programEntriesTester struct {
+	oEntries []Entry
+	oErr     error
+}
+
+func (tester programEntriesTester) assertEntries() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		root := &Entry{children: make(map[string]*Entry)}
+		for i, e := range tester.oEntries {
+			root.children[e.name] = &tester.oEntries[i]
+		}
+		sampleProgram := &Program{root: root}
+		got, gotErr := sampleProgram.Entries()
+		assertNilError(t, gotErr)
+		assertEntries(t, got, tester.oEntries)
+	}
+}
+
+func (tester programEntriesTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		_, got := sampleProgram.Entries()
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programAddArgTester struct {
+	iArg string
+	oErr error
+}
+
+func (tester programAddArgTester) assertArgs() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: &Entry{args: make([]string, 0)}}
+		gotErr := sampleProgram.AddArg(tester.iArg)
+		assertNilError(t, gotErr)
+		assertArgs(t, sampleProgram.root.args, []string{tester.iArg})
+	}
+}
+
+func (tester programAddArgTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.AddArg(tester.iArg)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programAddOptionTester struct {
+	iOption   *Option
+	iTemplate *template.Template
+	oErr      error
+}
+
+func (tester programAddOptionTester) assertOptions() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: &Entry{options: make([]Option, 0)}}
+		gotErr := sampleProgram.AddOption(tester.iOption)
+		assertNilError(t, gotErr)
+		assertOptions(t, sampleProgram.root.options, []Option{*tester.iOption})
+	}
+}
+
+func (tester programAddOptionTester) assertTemplate() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{
+			root:       &Entry{options: make([]Option, 0)},
+			optionTmpl: tester.iTemplate,
+		}
+		gotErr := sampleProgram.AddOption(tester.iOption)
+		assertNilError(t, gotErr)
+		assertTemplate(t, sampleProgram.root.options[0].tmpl, tester.iTemplate)
+		assertTemplate(t, tester.iOption.tmpl, nil)
+	}
+}
+
+func (tester programAddOptionTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.AddOption(tester.iOption)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programAddEntryTester struct {
+	iEntry    *Entry
+	iTemplate *template.Template
+	oErr      error
+}
+
+func (tester programAddEntryTester) assertChildren() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: &Entry{children: make(map[string]*Entry)}}
+		child := *tester.iEntry
+		gotErr := sampleProgram.AddEntry(&child)
+		assertNilError(t, gotErr)
+		assertParent(t, child.parent, sampleProgram.root)
+		assertChildren(t, sampleProgram.root.children, map[string]*Entry{child.name: &child})
+	}
+}
+
+func (tester programAddEntryTester) assertTemplate() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{
+			root:      &Entry{children: make(map[string]*Entry)},
+			entryTmpl: tester.iTemplate,
+		}
+		child := *tester.iEntry
+		gotErr := sampleProgram.AddEntry(&child)
+		assertNilError(t, gotErr)
+		assertTemplate(t, child.tmpl, tester.iTemplate)
+	}
+}
+
+func (tester programAddEntryTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.AddEntry(tester.iEntry)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSetNameTester struct {
+	iName string
+	oErr  error
+}
+
+func (tester programSetNameTester) assertName() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: &Entry{name: "foo"}}
+		gotErr := sampleProgram.SetName(tester.iName)
+		assertNilError(t, gotErr)
+		assertName(t, sampleProgram.root.name, tester.iName)
+	}
+}
+
+func (tester programSetNameTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.SetName(tester.iName)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programUsageTester struct {
+	oUsage string
+	oErr   error
+}
+
+func (tester programUsageTester) assertUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: stringToEntry(tester.oUsage)}
+		got, gotErr := sampleProgram.Usage()
+		assertNilError(t, gotErr)
+		assertUsage(t, got, tester.oUsage)
+	}
+}
+
+func (tester programUsageTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		_, got := sampleProgram.Usage()
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programUsageAllTester struct {
+	oUsage    string
+	oUsageAll string
+	oErr      error
+}
+
+func (tester programUsageAllTester) assertUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		sampleProgram.root.tmpl = template.Must(template.New("").Parse("{{range .Entries}}{{.Name}} {{end}}"))
+		sampleProgram.root.children["user"].hidden = true
+		gotUsage, _ := sampleProgram.Usage()
+		assertUsage(t, gotUsage, tester.oUsage)
+		got, gotErr := sampleProgram.UsageAll()
+		assertNilError(t, gotErr)
+		assertUsage(t, got, tester.oUsageAll)
+	}
+}
+
+func (tester programUsageAllTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		_, got := sampleProgram.UsageAll()
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programLookupTester struct {
+	iLookup string
+	oUsage  string
+	oErr    error
+}
+
+func (tester programLookupTester) assertUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		rawTmpl := `{{join (reverse .Ancestry) ":"}}`
+		fn := template.FuncMap{
+			"join":    strings.Join,
+			"reverse": reverseAncestryChain,
+		}
+		root := &Entry{
+			name:     "base",
+			children: make(map[string]*Entry),
+			tmpl:     template.Must(template.New("").Funcs(fn).Parse(rawTmpl)),
+		}
+		ptr := root
+		for i := 1; i <= 3; i++ {
+			entry := &Entry{
+				name:     fmt.Sprintf("level-%d", i),
+				children: make(map[string]*Entry),
+				parent:   ptr,
+				tmpl:     root.tmpl,
+			}
+			ptr.children[entry.name] = entry
+			ptr = entry
+		}
+		sampleProgram := &Program{root: root}
+		got, gotErr := sampleProgram.Lookup(tester.iLookup)
+		assertNilError(t, gotErr)
+		assertUsage(t, got, tester.oUsage)
+	}
+}
+
+func (tester programLookupTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		_, got := sampleProgram.Lookup(tester.iLookup)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programLookupPathTester struct {
+	iPath  []string
+	oUsage string
+	oErr   error
+}
+
+func (tester programLookupPathTester) assertUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		got, gotErr := sampleProgram.LookupPath(tester.iPath...)
+		assertNilError(t, gotErr)
+		assertUsage(t, got, tester.oUsage)
+	}
+}
+
+func (tester programLookupPathTester) assertNotFoundError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		_, got := sampleProgram.LookupPath(tester.iPath...)
+		assertNotFoundError(t, got, tester.oErr)
+	}
+}
+
+func (tester programLookupPathTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		_, got := sampleProgram.LookupPath(tester.iPath...)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programResolveTester struct {
+	iArgs []string
+	oPath string
+	oRest []string
+	oErr  error
+}
+
+func (tester programResolveTester) assertEntry() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		got, gotRest, gotErr := sampleProgram.Resolve(tester.iArgs)
+		assertNilError(t, gotErr)
+		assertPath(t, got.path(), tester.oPath)
+		assertArgs(t, gotRest, tester.oRest)
+	}
+}
+
+func (tester programResolveTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		_, _, got := sampleProgram.Resolve(tester.iArgs)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programParseTester struct {
+	iArgs []string
+	oPath string
+	oArgs []string
+	oErr  error
+}
+
+func (tester programParseTester) assertResult() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		got, gotErr := sampleProgram.Parse(tester.iArgs)
+		assertNilError(t, gotErr)
+		assertPath(t, got.Entry.path(), tester.oPath)
+		assertArgs(t, got.Args, tester.oArgs)
+	}
+}
+
+func (tester programParseTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		_, got := sampleProgram.Parse(tester.iArgs)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programEnableHelpTester struct {
+	iOption   *Option
+	iTemplate *template.Template
+	oErr      error
+}
+
+func (tester programEnableHelpTester) assertHelp() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		sampleProgram.SetOptionTemplate(tester.iTemplate)
+		sampleProgram.SetEntryTemplate(tester.iTemplate)
+		gotErr := sampleProgram.EnableHelp(tester.iOption)
+		assertNilError(t, gotErr)
+		assertTemplate(t, sampleProgram.root.help.tmpl, tester.iTemplate)
+		assertTemplate(t, sampleProgram.root.helpEntry.tmpl, tester.iTemplate)
+	}
+}
+
+func (tester programEnableHelpTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.EnableHelp(tester.iOption)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programEnableVersionTester struct {
+	iMode     VersionMode
+	iTemplate *template.Template
+	oErr      error
+}
+
+func (tester programEnableVersionTester) assertVersion() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		sampleProgram.SetOptionTemplate(tester.iTemplate)
+		sampleProgram.SetEntryTemplate(tester.iTemplate)
+		gotErr := sampleProgram.EnableVersion(tester.iMode, VersionInfo{Version: "v1.2.3"})
+		assertNilError(t, gotErr)
+		assertTemplate(t, sampleProgram.root.option("--version").tmpl, tester.iTemplate)
+		assertTemplate(t, sampleProgram.root.children["version"].tmpl, tester.iTemplate)
+	}
+}
+
+func (tester programEnableVersionTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.EnableVersion(tester.iMode, VersionInfo{})
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSetOrderTester struct {
+	oErr error
+}
+
+func (tester programSetOrderTester) assertOrder() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleEntry, _ := NewEntry("base", "")
+		for _, name := range []string{"status", "deploy"} {
+			entry, _ := NewEntry(name, "")
+			sampleEntry.AddEntry(entry)
+		}
+		for _, alias := range []string{"--verbose", "--config"} {
+			option, _ := NewOption([]string{alias}, "")
+			sampleEntry.AddOption(option)
+		}
+		sampleProgram := &Program{root: sampleEntry}
+		assertNilError(t, sampleProgram.SetEntryOrder(EntriesByInsertion))
+		assertNilError(t, sampleProgram.SetOptionOrder(OptionsByAlias))
+		entries, _ := sampleProgram.Entries()
+		assertName(t, entries[0].name, "status")
+		options, _ := sampleProgram.Options()
+		assertAliases(t, options[0].aliases, []string{"--config"})
+	}
+}
+
+func (tester programSetOrderTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		assertUninitializedProgramError(t, sampleProgram.SetEntryOrder(EntriesByName), tester.oErr)
+		assertUninitializedProgramError(t, sampleProgram.SetOptionOrder(OptionsByAlias), tester.oErr)
+	}
+}
+
+type programSetWarningOutputTester struct {
+	oErr error
+}
+
+func (tester programSetWarningOutputTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.SetWarningOutput(&strings.Builder{})
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSetErrorOutputTester struct {
+	oErr error
+}
+
+func (tester programSetErrorOutputTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.SetErrorOutput(&strings.Builder{})
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSetGateTester struct {
+	oErr error
+}
+
+func (tester programSetGateTester) assertGate() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		gotErr := sampleProgram.SetGate(&Gate{Enabled: true})
+		assertNilError(t, gotErr)
+		if !sampleProgram.root.gate.Enabled {
+			t.Error("gate was not set on the root")
+		}
+	}
+}
+
+func (tester programSetGateTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.SetGate(nil)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSetFooterTester struct {
+	oErr error
+}
+
+func (tester programSetFooterTester) assertFooter() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		gotErr := sampleProgram.SetFooter("Report bugs to bugs@example.com.")
+		assertNilError(t, gotErr)
+		assertFooter(t, sampleProgram.root.children["db"].Footer(), "Report bugs to bugs@example.com.")
+	}
+}
+
+func (tester programSetFooterTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.SetFooter("")
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSetExitCodeTester struct {
+	oErr error
+}
+
+func (tester programSetExitCodeTester) assertExitCode() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		gotErr := sampleProgram.SetExitCode(3, "The operation timed out.")
+		assertNilError(t, gotErr)
+		assertExitCodes(t, sampleProgram.root.children["db"].ExitCodes(), []ExitCode{{Code: 3, Meaning: "The operation timed out."}})
+	}
+}
+
+func (tester programSetExitCodeTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.SetExitCode(3, "The operation timed out.")
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSetOutputTester struct {
+	oErr error
+}
+
+func (tester programSetOutputTester) assertOutput() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		var b strings.Builder
+		gotErr := sampleProgram.SetOutput(&b)
+		assertNilError(t, gotErr)
+		sampleProgram.Run(context.Background(), []string{"db"})
+		assertUsage(t, b.String(), "base:db\n")
+	}
+}
+
+func (tester programSetOutputTester) assertNoWriterError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{root: newPathTree()}
+		got := sampleProgram.SetOutput(nil)
+		assertError(t, got, tester.oErr)
+	}
+}
+
+func (tester programSetOutputTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.SetOutput(&strings.Builder{})
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+func stubStdio(t *testing.T) (*os.File, *os.File) {
+	stdout, _ := os.CreateTemp(t.TempDir(), "stdout")
+	stderr, _ := os.CreateTemp(t.TempDir(), "stderr")
+	origStdout, origStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+	t.Cleanup(func() {
+		os.Stdout, os.Stderr = origStdout, origStderr
+	})
+	return stdout, stderr
+}
+
+type programDefaultOutputTester struct {
+	iArgs   []string
+	oStdout string
+	oStderr string
+}
+
+func (tester programDefaultOutputTester) assertOutput() func(*testing.T) {
+	return func(t *testing.T) {
+		defer stubBuildInfo(nil)()
+		stdout, stderr := stubStdio(t)
+		sampleProgram := &Program{root: newPathTree()}
+		sampleProgram.EnableHelp(nil)
+		sampleProgram.EnableVersion(VersionOption|VersionCommand, VersionInfo{Version: "v1.2.3"})
+		sampleProgram.Run(context.Background(), tester.iArgs)
+		gotStdout, _ := os.ReadFile(stdout.Name())
+		gotStderr, _ := os.ReadFile(stderr.Name())
+		assertUsage(t, string(gotStdout), tester.oStdout)
+		assertErrorOutput(t, string(gotStderr), tester.oStderr)
+	}
+}
+
+type programRunTester struct {
+	iArgs       []string
+	iHandlerErr error
+	oPath       []string
+	oArgs       []string
+	oUsage      string
+	oErr        error
+}
+
+func (tester programRunTester) run(t *testing.T) (*Invocation, string, error) {
+	sampleProgram := &Program{root: newPathTree()}
+	var b strings.Builder
+	sampleProgram.SetOutput(&b)
+	sampleProgram.EnableHelp(nil)
+	var got *Invocation
+	entry, _ := sampleProgram.Find("db", "create")
+	entry.SetHandler(func(ctx context.Context, inv *Invocation) error {
+		got = inv
+		return tester.iHandlerErr
+	})
+	err := sampleProgram.Run(context.Background(), tester.iArgs)
+	return got, b.String(), err
+}
+
+func (tester programRunTester) assertInvocation() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		got, gotUsage, gotErr := tester.run(t)
+		assertError(t, gotErr, tester.oErr)
+		if got == nil {
+			t.Fatal("handler was not called")
+		}
+		assertAncestry(t, got.Path, tester.oPath)
+		assertArgs(t, got.Args, tester.oArgs)
+		assertUsage(t, gotUsage, "")
+	}
+}
+
+func (tester programRunTester) assertUsageError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		got, gotUsage, gotErr := tester.run(t)
+		assertError(t, gotErr, tester.oErr)
+		if got != nil {
+			t.Error("handler was called")
+		}
+		assertUsage(t, gotUsage, tester.oUsage)
+	}
+}
+
+func (tester programRunTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.Run(context.Background(), tester.iArgs)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSetEntryTemplateTester struct {
+	iTemplate *template.Template
+	oErr      error
+}
+
+func (tester programSetEntryTemplateTester) assertTemplate() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		root := &Entry{name: "base", children: make(map[string]*Entry)}
+		root.children["foo"] = &Entry{name: "foo", parent: root}
+		sampleProgram := &Program{root: root}
+		gotErr := sampleProgram.SetEntryTemplate(tester.iTemplate)
+		assertNilError(t, gotErr)
+		visit(root, func(e *Entry) {
+			assertTemplate(t, e.tmpl, tester.iTemplate)
+		})
+		assertTemplate(t, sampleProgram.entryTmpl, tester.iTemplate)
+	}
+}
+
+func (tester programSetEntryTemplateTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		root := &Entry{name: "base", tree: newSealedTree()}
+		sampleProgram := &Program{root: root}
+		got := sampleProgram.SetEntryTemplate(tester.iTemplate)
+		assertSealedError(t, got, tester.oErr)
+		assertTemplate(t, root.tmpl, nil)
+	}
+}
+
+func (tester programSetEntryTemplateTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.SetEntryTemplate(tester.iTemplate)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSetOptionTemplateTester struct {
+	iTemplate *template.Template
+	oErr      error
+}
+
+func (tester programSetOptionTemplateTester) assertTemplate() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		root := &Entry{
+			name:     "base",
+			children: make(map[string]*Entry),
+			options:  []Option{{}, {}},
+		}
+		root.children["foo"] = &Entry{name: "foo", parent: root, options: []Option{{}}}
+		sampleProgram := &Program{root: root}
+		gotErr := sampleProgram.SetOptionTemplate(tester.iTemplate)
+		assertNilError(t, gotErr)
+		visit(root, func(e *Entry) {
+			for _, option := range e.options {
+				assertTemplate(t, option.tmpl, tester.iTemplate)
+			}
+		})
+		assertTemplate(t, sampleProgram.optionTmpl, tester.iTemplate)
+	}
+}
+
+func (tester programSetOptionTemplateTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		root := &Entry{name: "base", options: []Option{{}}, tree: newSealedTree()}
+		sampleProgram := &Program{root: root}
+		got := sampleProgram.SetOptionTemplate(tester.iTemplate)
+		assertSealedError(t, got, tester.oErr)
+		assertTemplate(t, root.options[0].tmpl, nil)
+	}
+}
+
+func (tester programSetOptionTemplateTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.SetOptionTemplate(tester.iTemplate)
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programSealTester struct {
+	oErr error
+}
+
+func (tester programSealTester) assertSealed() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram, _ := NewProgram("base")
+		child, _ := NewEntry("child", "")
+		sampleProgram.AddEntry(child)
+		gotErr := sampleProgram.Seal()
+		assertNilError(t, gotErr)
+		assertSealed(t, child.Sealed(), true)
+	}
+}
+
+func (tester programSealTester) assertUninitializedProgramError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram := &Program{}
+		got := sampleProgram.Seal()
+		assertUninitializedProgramError(t, got, tester.oErr)
+	}
+}
+
+type programConcurrencyTester struct {
+	iWorkers  int
+	iTemplate *template.Template
+}
+
+func (tester programConcurrencyTester) assertTemplate() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram, _ := NewProgram("base")
+		var wg sync.WaitGroup
+		for i := 0; i < tester.iWorkers; i++ {
+			wg.Add(3)
+			go func(i int) {
+				defer wg.Done()
+				child, _ := NewEntry(fmt.Sprintf("child-%d", i), "")
+				assertNilError(t, sampleProgram.AddEntry(child))
+			}(i)
+			go func() {
+				defer wg.Done()
+				assertNilError(t, sampleProgram.SetEntryTemplate(tester.iTemplate))
+			}()
+			go func() {
+				defer wg.Done()
+				sampleProgram.Usage()
+			}()
+		}
+		wg.Wait()
+		visit(sampleProgram.root, func(e *Entry) {
+			assertTemplate(t, e.tmpl, tester.iTemplate)
+		})
+	}
+}
+
+type newProgramTester struct {
+	iName string
+	oErr  error
+}
+
+func (tester newProgramTester) assertRoot() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		got, gotErr := NewProgram(tester.iName)
+		assertNilError(t, gotErr)
+		assertDefaultEntry(t, got.root, &Entry{name: tester.iName})
+	}
+}
+
+func (tester newProgramTester) assertEmptyNameStringError() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		gotProgram, got := NewProgram(tester.iName)
+		assertNilProgram(t, gotProgram)
+		assertEmptyNameStringError(t, got, tester.oErr)
+	}
+}
+
+func TestProgramRoot(t *testing.T) {
+	t.Run("baseline", programRootTester{}.assertRoot())
+	t.Run("uninitialized", programRootTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramArgs(t *testing.T) {
+	t.Run("baseline", programArgsTester{
+		oArgs: []string{"foo"},
+	}.assertArgs())
+	t.Run("uninitialized", programArgsTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramOptions(t *testing.T) {
+	t.Run("baseline", programOptionsTester{
+		oOptions: []Option{{
+			Description: "foo",
+			aliases:     []string{"foo"},
+			args:        []string{"foo"},
+		}},
+	}.assertOptions())
+	t.Run("uninitialized", programOptionsTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramEntries(t *testing.T) {
+	t.Run("baseline", programEntriesTester{
+		oEntries: []Entry{{
+			Description: "foo",
+			name:        "foo",
+			args:        []string{"foo"},
+		}},
+	}.assertEntries())
+	t.Run("uninitialized", programEntriesTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramAddArg(t *testing.T) {
+	t.Run("baseline", programAddArgTester{
+		iArg: "foo",
+	}.assertArgs())
+	t.Run("uninitialized", programAddArgTester{
+		iArg: "foo",
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramAddOption(t *testing.T) {
+	t.Run("baseline", programAddOptionTester{
+		iOption: &Option{
+			Description: "foo",
+			aliases:     []string{"foo"},
+			args:        []string{"foo"},
+		},
+	}.assertOptions())
+	t.Run("program template", programAddOptionTester{
+		iOption:   &Option{aliases: []string{"foo"}},
+		iTemplate: template.Must(template.New("").Parse("foo")),
+	}.assertTemplate())
+	t.Run("uninitialized", programAddOptionTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramAddEntry(t *testing.T) {
+	t.Run("baseline", programAddEntryTester{
+		iEntry: &Entry{name: "foo"},
+	}.assertChildren())
+	t.Run("program template", programAddEntryTester{
+		iEntry:    &Entry{name: "foo"},
+		iTemplate: template.Must(template.New("").Parse("foo")),
+	}.assertTemplate())
+	t.Run("uninitialized", programAddEntryTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSetName(t *testing.T) {
+	t.Run("baseline", programSetNameTester{
+		iName: "bar",
+	}.assertName())
+	t.Run("uninitialized", programSetNameTester{
+		iName: "bar",
+		oErr:  errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramUsage(t *testing.T) {
+	t.Run("baseline", programUsageTester{
+		oUsage: "base",
+	}.assertUsage())
+	t.Run("ancestry options entries", programUsageTester{
+		oUsage: "parent:base [options] <command>",
+	}.assertUsage())
+	t.Run("uninitialized", programUsageTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramUsageAll(t *testing.T) {
+	t.Run("baseline", programUsageAllTester{
+		oUsage:    "db ",
+		oUsageAll: "db user ",
+	}.assertUsage())
+	t.Run("uninitialized", programUsageAllTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramLookup(t *testing.T) {
+	t.Run("baseline", programLookupTester{
+		iLookup: "level-1",
+		oUsage:  "base:level-1",
+	}.assertUsage())
+	t.Run("leaf lookup", programLookupTester{
+		iLookup: "level-3",
+		oUsage:  "base:level-1:level-2:level-3",
+	}.assertUsage())
+	t.Run("untracked entry", programLookupTester{
+		iLookup: "foo",
+	}.assertUsage())
+	t.Run("uninitialized", programLookupTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramLookupPath(t *testing.T) {
+	t.Run("baseline", programLookupPathTester{
+		iPath:  []string{"user", "create"},
+		oUsage: "base:user:create",
+	}.assertUsage())
+	t.Run("bare name", programLookupPathTester{
+		iPath: []string{"drop"},
+		oErr:  errors.New("usage: entry 'drop' not found in 'base'"),
+	}.assertNotFoundError())
+	t.Run("uninitialized", programLookupPathTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramResolve(t *testing.T) {
+	t.Run("baseline", programResolveTester{
+		iArgs: []string{"-v", "db", "create", "foo"},
+		oPath: "base db create",
+		oRest: []string{"foo"},
+	}.assertEntry())
+	t.Run("uninitialized", programResolveTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramParse(t *testing.T) {
+	t.Run("baseline", programParseTester{
+		iArgs: []string{"-v", "--config=foo.yml", "db", "create", "foo"},
+		oPath: "base db create",
+		oArgs: []string{"foo"},
+	}.assertResult())
+	t.Run("uninitialized", programParseTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramEnableHelp(t *testing.T) {
+	t.Run("baseline", programEnableHelpTester{
+		iTemplate: template.Must(template.New("").Parse("foo")),
+	}.assertHelp())
+	t.Run("custom option", programEnableHelpTester{
+		iOption:   &Option{aliases: []string{"--usage"}},
+		iTemplate: template.Must(template.New("").Parse("foo")),
+	}.assertHelp())
+	t.Run("uninitialized", programEnableHelpTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramEnableVersion(t *testing.T) {
+	t.Run("baseline", programEnableVersionTester{
+		iMode:     VersionOption | VersionCommand,
+		iTemplate: template.Must(template.New("").Parse("foo")),
+	}.assertVersion())
+	t.Run("uninitialized", programEnableVersionTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSetOrder(t *testing.T) {
+	t.Run("baseline", programSetOrderTester{}.assertOrder())
+	t.Run("uninitialized", programSetOrderTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSetWarningOutput(t *testing.T) {
+	t.Run("uninitialized", programSetWarningOutputTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSetErrorOutput(t *testing.T) {
+	t.Run("uninitialized", programSetErrorOutputTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSetGate(t *testing.T) {
+	t.Run("baseline", programSetGateTester{}.assertGate())
+	t.Run("uninitialized", programSetGateTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSetFooter(t *testing.T) {
+	t.Run("baseline", programSetFooterTester{}.assertFooter())
+	t.Run("uninitialized", programSetFooterTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSetExitCode(t *testing.T) {
+	t.Run("baseline", programSetExitCodeTester{}.assertExitCode())
+	t.Run("uninitialized", programSetExitCodeTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSetOutput(t *testing.T) {
+	t.Run("baseline", programSetOutputTester{}.assertOutput())
+	t.Run("no writer", programSetOutputTester{
+		oErr: errors.New("usage: no writer provided"),
+	}.assertNoWriterError())
+	t.Run("uninitialized", programSetOutputTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramRun(t *testing.T) {
+	t.Run("baseline", programRunTester{
+		iArgs: []string{"-v", "db", "create", "foo"},
+		oPath: []string{"base", "db", "create"},
+		oArgs: []string{"foo"},
+	}.assertInvocation())
+	t.Run("handler error", programRunTester{
+		iArgs:       []string{"db", "create", "foo"},
+		iHandlerErr: context.Canceled,
+		oPath:       []string{"base", "db", "create"},
+		oArgs:       []string{"foo"},
+		oErr:        context.Canceled,
+	}.assertInvocation())
+	t.Run("no handler", programRunTester{
+		iArgs:  []string{"db"},
+		oUsage: "base:db\n",
+		oErr:   errors.New("usage: no handler for 'base db'"),
+	}.assertUsageError())
+	t.Run("help", programRunTester{
+		iArgs:  []string{"db", "create", "--help"},
+		oUsage: "base:db:create\n",
+	}.assertUsageError())
+	t.Run("missing arg", programRunTester{
+		iArgs:  []string{"db", "create"},
+		oUsage: "base:db:create\n",
+		oErr:   errors.New("usage: missing argument <name> for 'base db create [options] <name>'"),
+	}.assertUsageError())
+	t.Run("parse error", programRunTester{
+		iArgs:  []string{"db", "crate"},
+		oUsage: "base:db\n",
+		oErr:   errors.New("usage: unknown command 'crate' for 'base db'; did you mean 'create'?"),
+	}.assertUsageError())
+	t.Run("uninitialized", programRunTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramDefaultOutput(t *testing.T) {
+	t.Run("help option", programDefaultOutputTester{
+		iArgs:   []string{"db", "--help"},
+		oStdout: "base:db\n",
+	}.assertOutput())
+	t.Run("help command", programDefaultOutputTester{
+		iArgs:   []string{"help", "db", "create"},
+		oStdout: "base:db:create\n",
+	}.assertOutput())
+	t.Run("version option", programDefaultOutputTester{
+		iArgs:   []string{"--version"},
+		oStdout: "v1.2.3\n",
+	}.assertOutput())
+	t.Run("version command", programDefaultOutputTester{
+		iArgs:   []string{"version"},
+		oStdout: "v1.2.3\n",
+	}.assertOutput())
+	t.Run("parse error", programDefaultOutputTester{
+		iArgs:   []string{"db", "crate"},
+		oStderr: "base:db\n",
+	}.assertOutput())
+}
+
+func TestProgramSetEntryTemplate(t *testing.T) {
+	t.Run("baseline", programSetEntryTemplateTester{
+		iTemplate: template.Must(template.New("").Parse("foo")),
+	}.assertTemplate())
+	t.Run("sealed", programSetEntryTemplateTester{
+		iTemplate: template.Must(template.New("").Parse("foo")),
+		oErr:      errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+	t.Run("uninitialized", programSetEntryTemplateTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSetOptionTemplate(t *testing.T) {
+	t.Run("baseline", programSetOptionTemplateTester{
+		iTemplate: template.Must(template.New("").Parse("foo")),
+	}.assertTemplate())
+	t.Run("sealed", programSetOptionTemplateTester{
+		iTemplate: template.Must(template.New("").Parse("foo")),
+		oErr:      errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+	t.Run("uninitialized", programSetOptionTemplateTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func TestProgramSeal(t *testing.T) {
+	t.Run("baseline", programSealTester{}.assertSealed())
+	t.Run("uninitialized", programSealTester{
+		oErr: errors.New("usage: program not initialized"),
+	}.assertUninitializedProgramError())
+}
+
+func (tester programConcurrencyTester) assertSealedOutput() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		sampleProgram, _ := NewProgram("base")
+		sampleProgram.EnableHelp(nil)
+		old, _ := NewEntry("old", "")
+		old.SetDeprecated(&Deprecation{Replacement: "new"})
+		old.SetHandler(func(context.Context, *Invocation) error {
+			return nil
+		})
+		sampleProgram.AddEntry(old)
+		assertNilError(t, sampleProgram.Seal())
+		exit := osExit
+		osExit = func(int) {}
+		t.Cleanup(func() {
+			osExit = exit
+		})
+		var wg sync.WaitGroup
+		for i := 0; i < tester.iWorkers; i++ {
+			wg.Add(6)
+			go func() {
+				defer wg.Done()
+				assertNilError(t, sampleProgram.SetOutput(io.Discard))
+			}()
+			go func() {
+				defer wg.Done()
+				assertNilError(t, sampleProgram.Run(context.Background(), []string{"--help"}))
+			}()
+			go func() {
+				defer wg.Done()
+				assertNilError(t, sampleProgram.SetWarningOutput(io.Discard))
+			}()
+			go func() {
+				defer wg.Done()
+				assertNilError(t, sampleProgram.Run(context.Background(), []string{"old"}))
+			}()
+			go func() {
+				defer wg.Done()
+				assertNilError(t, sampleProgram.SetErrorOutput(io.Discard))
+			}()
+			go func() {
+				defer wg.Done()
+				sampleProgram.Exit(errors.New("boom"))
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestProgramConcurrency(t *testing.T) {
+	t.Run("baseline", programConcurrencyTester{
+		iWorkers:  32,
+		iTemplate: template.Must(template.New("").Parse("foo")),
+	}.assertTemplate())
+	t.Run("sealed output", programConcurrencyTester{
+		iWorkers: 32,
+	}.assertSealedOutput())
+}
+
+func TestNewProgram(t *testing.T) {
+	t.Run("baseline", newProgramTester{
+		iName: "foo",
+	}.assertRoot())
+	t.Run("empty name string", newProgramTester{
+		oErr: errors.New("usage: name string must not be empty"),
+	}.assertEmptyNameStringError())
+}
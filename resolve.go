@@ -0,0 +1,74 @@
+package usage
+
+import "strings"
+
+func (e *Entry) Resolve(args []string) (*Entry, []string, error) {
+	defer e.rlock()()
+	ptr, rest := e, args
+	for i := 0; i < len(args); i++ {
+		token := args[i]
+		if token == "--" {
+			break
+		}
+		if isOptionToken(token) {
+			alias, _, hasValue := strings.Cut(token, "=")
+			option := ptr.option(alias)
+			if option == nil {
+				break
+			}
+			if !hasValue {
+				i += len(option.args)
+			}
+			continue
+		}
+		if len(ptr.children) == 0 {
+			break
+		}
+		child, err := ptr.match(token)
+		if err != nil {
+			return ptr, args[i:], err
+		}
+		if child == nil {
+			return ptr, args[i:], unknownError("command", token, ptr.path(), suggest(token, ptr.commandNames()))
+		}
+		ptr, rest = child, args[i+1:]
+	}
+	return ptr, rest, nil
+}
+
+func (e *Entry) option(alias string) *Option {
+	return e.findOption(func(a string) bool {
+		return a == alias
+	})
+}
+
+func (e *Entry) findOption(match func(alias string) bool) *Option {
+	matches := func(option Option) bool {
+		for _, a := range option.aliases {
+			if match(a) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range e.options {
+		if !e.optionGated(e.options[i]) && matches(e.options[i]) {
+			return &e.options[i]
+		}
+	}
+	for ptr := e.parent; ptr != nil; ptr = ptr.parent {
+		for i := range ptr.options {
+			if !ptr.optionGated(ptr.options[i]) && ptr.options[i].persistent && matches(ptr.options[i]) {
+				return &ptr.options[i]
+			}
+		}
+	}
+	if help := e.helpOption(); help != nil && matches(*help) {
+		return help
+	}
+	return nil
+}
+
+func isOptionToken(token string) bool {
+	return len(token) > 1 && strings.HasPrefix(token, "-")
+}
@@ -0,0 +1,130 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entryResolveTester struct {
+	iArgs []string
+	oPath string
+	oRest []string
+	oErr  error
+}
+
+func (tester entryResolveTester) assertEntry() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		got, gotRest, gotErr := sampleEntry.Resolve(tester.iArgs)
+		assertNilError(t, gotErr)
+		assertPath(t, got.path(), tester.oPath)
+		assertArgs(t, gotRest, tester.oRest)
+	}
+}
+
+func (tester entryResolveTester) assertUnknownCommandError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		gotEntry, gotRest, got := sampleEntry.Resolve(tester.iArgs)
+		assertUnknownCommandError(t, got, tester.oErr)
+		assertPath(t, gotEntry.path(), tester.oPath)
+		assertArgs(t, gotRest, tester.oRest)
+	}
+}
+
+type resolveTester struct {
+	iArgs  []string
+	oPath  string
+	oRest  []string
+	oPanic error
+}
+
+func (tester resolveTester) assertEntry() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		got, gotRest, gotErr := Resolve(tester.iArgs)
+		assertNilError(t, gotErr)
+		assertPath(t, got.path(), tester.oPath)
+		assertArgs(t, gotRest, tester.oRest)
+		global = nil
+	}
+}
+
+func (tester resolveTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		Resolve(tester.iArgs)
+		assertNilProgram(t, global)
+	}
+}
+
+func TestEntryResolve(t *testing.T) {
+	t.Run("baseline", entryResolveTester{
+		iArgs: []string{"db", "create", "foo"},
+		oPath: "base db create",
+		oRest: []string{"foo"},
+	}.assertEntry())
+	t.Run("aliases", entryResolveTester{
+		iArgs: []string{"database", "rm"},
+		oPath: "base db drop",
+		oRest: []string{},
+	}.assertEntry())
+	t.Run("no args", entryResolveTester{
+		iArgs: []string{},
+		oPath: "base",
+		oRest: []string{},
+	}.assertEntry())
+	t.Run("intermediate entry", entryResolveTester{
+		iArgs: []string{"db"},
+		oPath: "base db",
+		oRest: []string{},
+	}.assertEntry())
+	t.Run("leading options", entryResolveTester{
+		iArgs: []string{"-v", "--config", "foo.yml", "db", "create"},
+		oPath: "base db create",
+		oRest: []string{},
+	}.assertEntry())
+	t.Run("inline option value", entryResolveTester{
+		iArgs: []string{"--config=foo.yml", "user"},
+		oPath: "base user",
+		oRest: []string{},
+	}.assertEntry())
+	t.Run("nested options", entryResolveTester{
+		iArgs: []string{"db", "--force", "create", "--help", "foo"},
+		oPath: "base db create",
+		oRest: []string{"--help", "foo"},
+	}.assertEntry())
+	t.Run("option of wrong level", entryResolveTester{
+		iArgs: []string{"--force", "db", "create"},
+		oPath: "base",
+		oRest: []string{"--force", "db", "create"},
+	}.assertEntry())
+	t.Run("terminator", entryResolveTester{
+		iArgs: []string{"db", "--", "create"},
+		oPath: "base db",
+		oRest: []string{"--", "create"},
+	}.assertEntry())
+	t.Run("unknown command", entryResolveTester{
+		iArgs: []string{"db", "foo", "bar"},
+		oPath: "base db",
+		oRest: []string{"foo", "bar"},
+		oErr:  errors.New("usage: unknown command 'foo' for 'base db'"),
+	}.assertUnknownCommandError())
+	t.Run("unknown command suggestion", entryResolveTester{
+		iArgs: []string{"db", "crate"},
+		oPath: "base db",
+		oRest: []string{"crate"},
+		oErr:  errors.New("usage: unknown command 'crate' for 'base db'; did you mean 'create'?"),
+	}.assertUnknownCommandError())
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("baseline", resolveTester{
+		iArgs: []string{"user", "create"},
+		oPath: "base user create",
+		oRest: []string{},
+	}.assertEntry())
+	t.Run("uninitialized", resolveTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
@@ -0,0 +1,33 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrNoHandler = errors.New("no handler")
+
+type Handler func(ctx context.Context, inv *Invocation) error
+
+type Invocation struct {
+	*Result
+}
+
+func (e *Entry) Handler() Handler {
+	defer e.rlock()()
+	return e.handler
+}
+
+func (e *Entry) SetHandler(handler Handler) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	e.handler = handler
+	return nil
+}
+
+func noHandlerError(path string) error {
+	return &UsageError{fmt.Errorf("%w for '%s'", ErrNoHandler, path)}
+}
@@ -0,0 +1,106 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type entrySetHandlerTester struct {
+	iHandler Handler
+	oErr     error
+}
+
+func (tester entrySetHandlerTester) assertHandler() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo"}
+		gotErr := sampleEntry.SetHandler(tester.iHandler)
+		assertNilError(t, gotErr)
+		if (sampleEntry.Handler() == nil) != (tester.iHandler == nil) {
+			t.Error("handler was not set")
+		}
+	}
+}
+
+func (tester entrySetHandlerTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", tree: newSealedTree()}
+		got := sampleEntry.SetHandler(tester.iHandler)
+		assertSealedError(t, got, tester.oErr)
+		if sampleEntry.Handler() != nil {
+			t.Error("handler was set on sealed entry")
+		}
+	}
+}
+
+type noHandlerErrorTester struct {
+	iPath string
+	oErr  error
+}
+
+func (tester noHandlerErrorTester) assertError() func(*testing.T) {
+	return func(t *testing.T) {
+		got := noHandlerError(tester.iPath)
+		assertError(t, got, tester.oErr)
+		if !errors.Is(got, ErrNoHandler) {
+			t.Error("error does not wrap ErrNoHandler")
+		}
+	}
+}
+
+type runTester struct {
+	iArgs  []string
+	oUsage string
+	oErr   error
+	oPanic error
+}
+
+func (tester runTester) assertUsageError() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		var b strings.Builder
+		SetOutput(&b)
+		got := Run(context.Background(), tester.iArgs)
+		assertError(t, got, tester.oErr)
+		assertUsage(t, b.String(), tester.oUsage)
+		global = nil
+	}
+}
+
+func (tester runTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		Run(context.Background(), tester.iArgs)
+		assertNilProgram(t, global)
+	}
+}
+
+func TestEntrySetHandler(t *testing.T) {
+	t.Run("baseline", entrySetHandlerTester{
+		iHandler: func(context.Context, *Invocation) error { return nil },
+	}.assertHandler())
+	t.Run("nil handler", entrySetHandlerTester{}.assertHandler())
+	t.Run("sealed", entrySetHandlerTester{
+		iHandler: func(context.Context, *Invocation) error { return nil },
+		oErr:     errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestNoHandlerError(t *testing.T) {
+	t.Run("baseline", noHandlerErrorTester{
+		iPath: "base db",
+		oErr:  errors.New("usage: no handler for 'base db'"),
+	}.assertError())
+}
+
+func TestRun(t *testing.T) {
+	t.Run("no handler", runTester{
+		iArgs:  []string{"user"},
+		oUsage: "base:user\n",
+		oErr:   errors.New("usage: no handler for 'base user'"),
+	}.assertUsageError())
+	t.Run("uninitialized", runTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
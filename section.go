@@ -0,0 +1,56 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Section struct {
+	Heading string
+	Body    string
+}
+
+func (e *Entry) Sections() []Section {
+	defer e.rlock()()
+	return append(make([]Section, 0, len(e.sections)), e.sections...)
+}
+
+func (e *Entry) AddSection(heading, body string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if heading == "" {
+		return &UsageError{errors.New("heading string must not be empty")}
+	}
+	for _, section := range e.sections {
+		if section.Heading == heading {
+			return &UsageError{fmt.Errorf("section '%s' already in use in '%s'", heading, e.path())}
+		}
+	}
+	e.sections = append(e.sections, Section{Heading: heading, Body: body})
+	return nil
+}
+
+func (e *Entry) Footer() string {
+	defer e.rlock()()
+	return e.footer()
+}
+
+func (e *Entry) SetFooter(footer string) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	e.footerText = &footer
+	return nil
+}
+
+func (e *Entry) footer() string {
+	for ptr := e; ptr != nil; ptr = ptr.parent {
+		if ptr.footerText != nil {
+			return *ptr.footerText
+		}
+	}
+	return ""
+}
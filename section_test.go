@@ -0,0 +1,144 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entryAddSectionTester struct {
+	iHeading  string
+	iBody     string
+	oSections []Section
+	oErr      error
+}
+
+func (tester entryAddSectionTester) assertSections() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.AddSection("Files", "~/.baserc")
+		gotErr := sampleEntry.AddSection(tester.iHeading, tester.iBody)
+		assertNilError(t, gotErr)
+		assertSections(t, sampleEntry.Sections(), tester.oSections)
+	}
+}
+
+func (tester entryAddSectionTester) assertSectionError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.AddSection("Files", "~/.baserc")
+		got := sampleEntry.AddSection(tester.iHeading, tester.iBody)
+		assertSectionError(t, got, tester.oErr)
+		assertSections(t, sampleEntry.Sections(), []Section{{Heading: "Files", Body: "~/.baserc"}})
+	}
+}
+
+func (tester entryAddSectionTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", tree: newSealedTree()}
+		got := sampleEntry.AddSection(tester.iHeading, tester.iBody)
+		assertSealedError(t, got, tester.oErr)
+	}
+}
+
+type entryFooterTester struct {
+	iFooters map[string]string
+	iPath    []string
+	oFooter  string
+}
+
+func (tester entryFooterTester) assertFooter() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		for path, footer := range tester.iFooters {
+			entry := sampleEntry
+			if path != "" {
+				entry, _ = sampleEntry.Find(path)
+			}
+			entry.SetFooter(footer)
+		}
+		entry, _ := sampleEntry.Find(tester.iPath...)
+		assertFooter(t, entry.Footer(), tester.oFooter)
+	}
+}
+
+type setFooterTester struct {
+	oPanic error
+}
+
+func (tester setFooterTester) assertFooter() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		gotErr := SetFooter("Report bugs to bugs@example.com.")
+		assertNilError(t, gotErr)
+		entry, _ := Find("user", "create")
+		assertFooter(t, entry.Footer(), "Report bugs to bugs@example.com.")
+		global = nil
+	}
+}
+
+func (tester setFooterTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		SetFooter("")
+		assertNilProgram(t, global)
+	}
+}
+
+func TestEntryAddSection(t *testing.T) {
+	t.Run("baseline", entryAddSectionTester{
+		iHeading: "See Also",
+		iBody:    "git(1)",
+		oSections: []Section{
+			{Heading: "Files", Body: "~/.baserc"},
+			{Heading: "See Also", Body: "git(1)"},
+		},
+	}.assertSections())
+	t.Run("empty body", entryAddSectionTester{
+		iHeading: "Reporting Bugs",
+		oSections: []Section{
+			{Heading: "Files", Body: "~/.baserc"},
+			{Heading: "Reporting Bugs"},
+		},
+	}.assertSections())
+	t.Run("empty heading string", entryAddSectionTester{
+		iBody: "git(1)",
+		oErr:  errors.New("usage: heading string must not be empty"),
+	}.assertSectionError())
+	t.Run("duplicate heading", entryAddSectionTester{
+		iHeading: "Files",
+		iBody:    "/etc/base.conf",
+		oErr:     errors.New("usage: section 'Files' already in use in 'base'"),
+	}.assertSectionError())
+	t.Run("sealed", entryAddSectionTester{
+		iHeading: "Files",
+		oErr:     errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryFooter(t *testing.T) {
+	t.Run("baseline", entryFooterTester{
+		iFooters: map[string]string{"": "Report bugs to bugs@example.com."},
+		iPath:    []string{"db"},
+		oFooter:  "Report bugs to bugs@example.com.",
+	}.assertFooter())
+	t.Run("override", entryFooterTester{
+		iFooters: map[string]string{"": "Report bugs to bugs@example.com.", "db": "Report bugs to db@example.com."},
+		iPath:    []string{"db", "create"},
+		oFooter:  "Report bugs to db@example.com.",
+	}.assertFooter())
+	t.Run("cleared", entryFooterTester{
+		iFooters: map[string]string{"": "Report bugs to bugs@example.com.", "db": ""},
+		iPath:    []string{"db"},
+		oFooter:  "",
+	}.assertFooter())
+	t.Run("no footer", entryFooterTester{
+		iPath: []string{"user", "create"},
+	}.assertFooter())
+}
+
+func TestSetFooter(t *testing.T) {
+	t.Run("baseline", setFooterTester{}.assertFooter())
+	t.Run("uninitialized", setFooterTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
@@ -0,0 +1,103 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+type Stability int
+
+const (
+	Stable Stability = iota
+	Beta
+	Experimental
+)
+
+func (s Stability) String() string {
+	switch s {
+	case Stable:
+		return "stable"
+	case Beta:
+		return "beta"
+	case Experimental:
+		return "experimental"
+	}
+	return fmt.Sprintf("Stability(%d)", int(s))
+}
+
+type Gate struct {
+	Env     string
+	Enabled bool
+}
+
+func (o Option) Stability() Stability {
+	return o.stability
+}
+
+func (o *Option) SetStability(stability Stability) error {
+	if stability < Stable || stability > Experimental {
+		return &UsageError{fmt.Errorf("unknown stability level %d", stability)}
+	}
+	o.stability = stability
+	return nil
+}
+
+func (e *Entry) Stability() Stability {
+	defer e.rlock()()
+	return e.stability
+}
+
+func (e *Entry) SetStability(stability Stability) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if stability < Stable || stability > Experimental {
+		return &UsageError{fmt.Errorf("unknown stability level %d", stability)}
+	}
+	e.stability = stability
+	return nil
+}
+
+func (e *Entry) SetGate(gate *Gate) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if gate == nil {
+		e.gate = nil
+		return nil
+	}
+	g := *gate
+	e.gate = &g
+	return nil
+}
+
+func (e *Entry) ExperimentalEnabled() bool {
+	defer e.rlock()()
+	return e.experimentalEnabled()
+}
+
+func (e *Entry) experimentalEnabled() bool {
+	for ptr := e; ptr != nil; ptr = ptr.parent {
+		if ptr.gate == nil {
+			continue
+		}
+		if ptr.gate.Enabled {
+			return true
+		}
+		enabled, _ := strconv.ParseBool(os.Getenv(ptr.gate.Env))
+		return ptr.gate.Env != "" && enabled
+	}
+	return true
+}
+
+func (e *Entry) gated() bool {
+	return e.stability == Experimental && !e.experimentalEnabled()
+}
+
+func (e *Entry) optionGated(option Option) bool {
+	return option.stability == Experimental && !e.experimentalEnabled()
+}
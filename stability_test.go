@@ -0,0 +1,210 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type stabilityStringTester struct {
+	iStability Stability
+	oString    string
+}
+
+func (tester stabilityStringTester) assertString() func(*testing.T) {
+	return func(t *testing.T) {
+		assertStability(t, tester.iStability.String(), tester.oString)
+	}
+}
+
+type setStabilityTester struct {
+	iStability Stability
+	oErr       error
+}
+
+func (tester setStabilityTester) assertStability() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--foo"}}
+		assertNilError(t, sampleOption.SetStability(tester.iStability))
+		sampleEntry := &Entry{name: "foo"}
+		assertNilError(t, sampleEntry.SetStability(tester.iStability))
+		assertStability(t, sampleOption.Stability().String(), tester.iStability.String())
+		assertStability(t, sampleEntry.Stability().String(), tester.iStability.String())
+	}
+}
+
+func (tester setStabilityTester) assertUnknownLevelError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleOption := &Option{aliases: []string{"--foo"}}
+		assertError(t, sampleOption.SetStability(tester.iStability), tester.oErr)
+		sampleEntry := &Entry{name: "foo"}
+		assertError(t, sampleEntry.SetStability(tester.iStability), tester.oErr)
+		if sampleOption.Stability() != Stable || sampleEntry.Stability() != Stable {
+			t.Error("unknown stability level was set")
+		}
+	}
+}
+
+func (tester setStabilityTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", tree: newSealedTree()}
+		assertSealedError(t, sampleEntry.SetStability(tester.iStability), tester.oErr)
+		assertSealedError(t, sampleEntry.SetGate(&Gate{}), tester.oErr)
+	}
+}
+
+type entryExperimentalEnabledTester struct {
+	iGate    *Gate
+	iEnv     string
+	oEnabled bool
+}
+
+func (tester entryExperimentalEnabledTester) assertEnabled() func(*testing.T) {
+	return func(t *testing.T) {
+		t.Setenv("TOOL_EXPERIMENTAL", tester.iEnv)
+		sampleEntry := newPathTree()
+		sampleEntry.SetGate(tester.iGate)
+		child, _ := sampleEntry.Find("db", "create")
+		if got := child.ExperimentalEnabled(); got != tester.oEnabled {
+			t.Errorf("experimental enabled is %t but should be %t", got, tester.oEnabled)
+		}
+	}
+}
+
+type entryGateTester struct {
+	iGate     *Gate
+	iArgs     []string
+	oFindErr  error
+	oParseErr error
+	oEntries  []string
+}
+
+func (tester entryGateTester) assertGate() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.SetGate(tester.iGate)
+		sampleEntry.children["user"].SetStability(Experimental)
+		sampleEntry.children["db"].SetStability(Beta)
+		sampleEntry.options[1].SetStability(Experimental)
+		_, gotFindErr := sampleEntry.Find("user", "create")
+		assertError(t, gotFindErr, tester.oFindErr)
+		_, gotParseErr := sampleEntry.Parse(tester.iArgs)
+		assertError(t, gotParseErr, tester.oParseErr)
+		got := make([]string, 0)
+		for _, entry := range sampleEntry.snapshot(true).Entries() {
+			got = append(got, entry.name)
+		}
+		assertEntryNames(t, got, tester.oEntries)
+	}
+}
+
+type setGateTester struct {
+	oErr   error
+	oPanic error
+}
+
+func (tester setGateTester) assertGate() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.children["user"].SetStability(Experimental)
+		global = &Program{root: sampleEntry}
+		gotErr := SetGate(&Gate{Env: "TOOL_EXPERIMENTAL_UNSET"})
+		assertNilError(t, gotErr)
+		_, got := Find("user")
+		assertNotFoundError(t, got, tester.oErr)
+		global = nil
+	}
+}
+
+func (tester setGateTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		SetGate(nil)
+		assertNilProgram(t, global)
+	}
+}
+
+func TestStabilityString(t *testing.T) {
+	t.Run("stable", stabilityStringTester{
+		iStability: Stable,
+		oString:    "stable",
+	}.assertString())
+	t.Run("beta", stabilityStringTester{
+		iStability: Beta,
+		oString:    "beta",
+	}.assertString())
+	t.Run("experimental", stabilityStringTester{
+		iStability: Experimental,
+		oString:    "experimental",
+	}.assertString())
+	t.Run("unknown", stabilityStringTester{
+		iStability: 5,
+		oString:    "Stability(5)",
+	}.assertString())
+}
+
+func TestSetStability(t *testing.T) {
+	t.Run("baseline", setStabilityTester{
+		iStability: Experimental,
+	}.assertStability())
+	t.Run("stable", setStabilityTester{
+		iStability: Stable,
+	}.assertStability())
+	t.Run("unknown level", setStabilityTester{
+		iStability: 3,
+		oErr:       errors.New("usage: unknown stability level 3"),
+	}.assertUnknownLevelError())
+	t.Run("sealed", setStabilityTester{
+		iStability: Beta,
+		oErr:       errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryExperimentalEnabled(t *testing.T) {
+	t.Run("no gate", entryExperimentalEnabledTester{
+		oEnabled: true,
+	}.assertEnabled())
+	t.Run("gated", entryExperimentalEnabledTester{
+		iGate: &Gate{Env: "TOOL_EXPERIMENTAL"},
+	}.assertEnabled())
+	t.Run("enabled by setting", entryExperimentalEnabledTester{
+		iGate:    &Gate{Enabled: true},
+		oEnabled: true,
+	}.assertEnabled())
+	t.Run("enabled by env", entryExperimentalEnabledTester{
+		iGate:    &Gate{Env: "TOOL_EXPERIMENTAL"},
+		iEnv:     "1",
+		oEnabled: true,
+	}.assertEnabled())
+	t.Run("disabled by env", entryExperimentalEnabledTester{
+		iGate: &Gate{Env: "TOOL_EXPERIMENTAL"},
+		iEnv:  "false",
+	}.assertEnabled())
+}
+
+func TestEntryGate(t *testing.T) {
+	t.Run("baseline", entryGateTester{
+		iGate:     &Gate{},
+		iArgs:     []string{"--config", "foo.yml"},
+		oFindErr:  errors.New("usage: entry 'user' not found in 'base'"),
+		oParseErr: errors.New("usage: unknown option '--config' for 'base'"),
+		oEntries:  []string{"db"},
+	}.assertGate())
+	t.Run("no gate", entryGateTester{
+		iArgs:    []string{"--config", "foo.yml"},
+		oEntries: []string{"db", "user"},
+	}.assertGate())
+	t.Run("enabled", entryGateTester{
+		iGate:    &Gate{Enabled: true},
+		iArgs:    []string{"--config", "foo.yml"},
+		oEntries: []string{"db", "user"},
+	}.assertGate())
+}
+
+func TestSetGate(t *testing.T) {
+	t.Run("baseline", setGateTester{
+		oErr: errors.New("usage: entry 'user' not found in 'base'"),
+	}.assertGate())
+	t.Run("uninitialized", setGateTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
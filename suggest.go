@@ -0,0 +1,124 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func (e *Entry) Suggest(token string) []string {
+	defer e.rlock()()
+	return suggest(token, append(e.commandNames(), e.optionAliases()...))
+}
+
+func (e *Entry) UnknownCommandError(token string) error {
+	defer e.rlock()()
+	return unknownError("command", token, e.path(), suggest(token, e.commandNames()))
+}
+
+func (e *Entry) UnknownOptionError(token string) error {
+	defer e.rlock()()
+	alias, _, _ := strings.Cut(token, "=")
+	return unknownError("option", alias, e.path(), suggest(alias, e.optionAliases()))
+}
+
+func (e *Entry) commandNames() []string {
+	names := make([]string, 0)
+	for _, child := range e.children {
+		if !child.hidden && !child.gated() {
+			names = append(names, child.names()...)
+		}
+	}
+	return names
+}
+
+func (e *Entry) optionAliases() []string {
+	aliases := make([]string, 0)
+	for _, option := range e.options {
+		if !option.hidden && !e.optionGated(option) {
+			aliases = append(aliases, option.aliases...)
+		}
+	}
+	for _, option := range e.globalOptions() {
+		if !option.hidden && !e.optionGated(option) {
+			aliases = append(aliases, option.aliases...)
+		}
+	}
+	if help := e.helpOption(); help != nil && !e.ownsAlias(*help) {
+		aliases = append(aliases, help.aliases...)
+	}
+	return aliases
+}
+
+func unknownError(kind, token, path string, suggestions []string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "unknown %s '%s' for '%s'", kind, token, path)
+	switch len(suggestions) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&b, "; did you mean '%s'?", suggestions[0])
+	default:
+		fmt.Fprintf(&b, "; did you mean one of '%s'?", strings.Join(suggestions, "', '"))
+	}
+	return &UsageError{errors.New(b.String())}
+}
+
+func suggest(token string, candidates []string) []string {
+	maxDistance := len(token) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+	distances := make(map[string]int)
+	for _, candidate := range candidates {
+		if candidate == token {
+			continue
+		}
+		if d := levenshtein(token, candidate); d <= maxDistance {
+			distances[candidate] = d
+		}
+	}
+	suggestions := make([]string, 0, len(distances))
+	for candidate := range distances {
+		suggestions = append(suggestions, candidate)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if distances[a] != distances[b] {
+			return distances[a] < distances[b]
+		}
+		return a < b
+	})
+	return suggestions
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,128 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+)
+
+type entrySuggestTester struct {
+	iToken       string
+	oSuggestions []string
+}
+
+func (tester entrySuggestTester) assertSuggestions() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name:     "base",
+			children: make(map[string]*Entry),
+			options: []Option{
+				{aliases: []string{"-v", "--verbose"}},
+				{aliases: []string{"--config"}, args: []string{"<file>"}},
+				{aliases: []string{"--debug"}, hidden: true},
+			},
+		}
+		for _, name := range []string{"deploy", "delete", "list", "deplay"} {
+			sampleEntry.children[name] = &Entry{name: name, parent: sampleEntry}
+		}
+		sampleEntry.children["list"].aliases = []string{"ls"}
+		sampleEntry.children["deplay"].hidden = true
+		got := sampleEntry.Suggest(tester.iToken)
+		assertSuggestions(t, got, tester.oSuggestions)
+	}
+}
+
+type entryUnknownErrorTester struct {
+	iToken string
+	oErr   error
+}
+
+func (tester entryUnknownErrorTester) assertUnknownCommandError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "base", children: make(map[string]*Entry)}
+		for _, name := range []string{"deploy", "delete", "list"} {
+			sampleEntry.children[name] = &Entry{name: name, parent: sampleEntry}
+		}
+		sampleEntry.children["list"].aliases = []string{"ls"}
+		got := sampleEntry.UnknownCommandError(tester.iToken)
+		assertUnknownCommandError(t, got, tester.oErr)
+	}
+}
+
+func (tester entryUnknownErrorTester) assertUnknownOptionError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{
+			name: "base",
+			options: []Option{
+				{aliases: []string{"-v", "--verbose"}},
+				{aliases: []string{"--config"}, args: []string{"<file>"}},
+			},
+		}
+		got := sampleEntry.UnknownOptionError(tester.iToken)
+		assertUnknownOptionError(t, got, tester.oErr)
+	}
+}
+
+func TestEntrySuggest(t *testing.T) {
+	t.Run("baseline", entrySuggestTester{
+		iToken:       "depoly",
+		oSuggestions: []string{"deploy"},
+	}.assertSuggestions())
+	t.Run("option", entrySuggestTester{
+		iToken:       "--verbsoe",
+		oSuggestions: []string{"--verbose"},
+	}.assertSuggestions())
+	t.Run("aliases", entrySuggestTester{
+		iToken:       "lst",
+		oSuggestions: []string{"list", "ls"},
+	}.assertSuggestions())
+	t.Run("ranked", entrySuggestTester{
+		iToken:       "lss",
+		oSuggestions: []string{"ls", "list"},
+	}.assertSuggestions())
+	t.Run("hidden", entrySuggestTester{
+		iToken:       "--debgu",
+		oSuggestions: []string{},
+	}.assertSuggestions())
+	t.Run("exact", entrySuggestTester{
+		iToken:       "deploy",
+		oSuggestions: []string{},
+	}.assertSuggestions())
+	t.Run("no suggestions", entrySuggestTester{
+		iToken:       "xyzzy",
+		oSuggestions: []string{},
+	}.assertSuggestions())
+}
+
+func TestEntryUnknownCommandError(t *testing.T) {
+	t.Run("baseline", entryUnknownErrorTester{
+		iToken: "depoly",
+		oErr:   errors.New("usage: unknown command 'depoly' for 'base'; did you mean 'deploy'?"),
+	}.assertUnknownCommandError())
+	t.Run("multiple suggestions", entryUnknownErrorTester{
+		iToken: "lst",
+		oErr:   errors.New("usage: unknown command 'lst' for 'base'; did you mean one of 'list', 'ls'?"),
+	}.assertUnknownCommandError())
+	t.Run("no suggestions", entryUnknownErrorTester{
+		iToken: "xyzzy",
+		oErr:   errors.New("usage: unknown command 'xyzzy' for 'base'"),
+	}.assertUnknownCommandError())
+	t.Run("options ignored", entryUnknownErrorTester{
+		iToken: "--verbsoe",
+		oErr:   errors.New("usage: unknown command '--verbsoe' for 'base'"),
+	}.assertUnknownCommandError())
+}
+
+func TestEntryUnknownOptionError(t *testing.T) {
+	t.Run("baseline", entryUnknownErrorTester{
+		iToken: "--verbsoe",
+		oErr:   errors.New("usage: unknown option '--verbsoe' for 'base'; did you mean '--verbose'?"),
+	}.assertUnknownOptionError())
+	t.Run("inline value", entryUnknownErrorTester{
+		iToken: "--confg=foo.yml",
+		oErr:   errors.New("usage: unknown option '--confg' for 'base'; did you mean '--config'?"),
+	}.assertUnknownOptionError())
+	t.Run("no suggestions", entryUnknownErrorTester{
+		iToken: "--xyzzy",
+		oErr:   errors.New("usage: unknown option '--xyzzy' for 'base'"),
+	}.assertUnknownOptionError())
+}
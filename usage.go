@@ -1,14 +1,16 @@
 package usage
 
 import (
+	"context"
 	"errors"
+	"io"
 	"text/template"
 )
 
-var global *Entry
+var global *Program
 
 func Init(name string) error {
-	glob, err := NewEntry(name, "")
+	glob, err := NewProgram(name)
 	if err != nil {
 		return err
 	}
@@ -18,17 +20,20 @@ func Init(name string) error {
 
 func Args() []string {
 	checkInit()
-	return global.Args()
+	args, _ := global.Args()
+	return args
 }
 
 func Options() []Option {
 	checkInit()
-	return global.Options()
+	options, _ := global.Options()
+	return options
 }
 
 func Entries() []Entry {
 	checkInit()
-	return global.Entries()
+	entries, _ := global.Entries()
+	return entries
 }
 
 func AddArg(arg string) error {
@@ -53,32 +58,124 @@ func SetName(name string) error {
 
 func Usage() string {
 	checkInit()
-	return global.Usage()
+	u, _ := global.Usage()
+	return u
+}
+
+func UsageAll() string {
+	checkInit()
+	u, _ := global.UsageAll()
+	return u
 }
 
 func Lookup(lookup string) string {
 	checkInit()
-	return global.Lookup(lookup)
+	u, _ := global.Lookup(lookup)
+	return u
+}
+
+func LookupPath(path ...string) (string, error) {
+	checkInit()
+	return global.LookupPath(path...)
+}
+
+func Find(path ...string) (*Entry, error) {
+	checkInit()
+	return global.Find(path...)
+}
+
+func Resolve(args []string) (*Entry, []string, error) {
+	checkInit()
+	return global.Resolve(args)
+}
+
+func SetMatching(matching Matching) error {
+	checkInit()
+	return global.SetMatching(matching)
+}
+
+func Parse(args []string) (*Result, error) {
+	checkInit()
+	return global.Parse(args)
+}
+
+func EnableHelp(option *Option) error {
+	checkInit()
+	return global.EnableHelp(option)
+}
+
+func EnableVersion(mode VersionMode, overrides VersionInfo) error {
+	checkInit()
+	return global.EnableVersion(mode, overrides)
+}
+
+func SetEntryOrder(less EntryLess) error {
+	checkInit()
+	return global.SetEntryOrder(less)
+}
+
+func SetOptionOrder(less OptionLess) error {
+	checkInit()
+	return global.SetOptionOrder(less)
+}
+
+func SetGate(gate *Gate) error {
+	checkInit()
+	return global.SetGate(gate)
+}
+
+func SetFooter(footer string) error {
+	checkInit()
+	return global.SetFooter(footer)
+}
+
+func SetExitCode(code int, meaning string, errs ...error) error {
+	checkInit()
+	return global.SetExitCode(code, meaning, errs...)
+}
+
+func SetOutput(w io.Writer) error {
+	checkInit()
+	return global.SetOutput(w)
+}
+
+func SetWarningOutput(w io.Writer) error {
+	checkInit()
+	return global.SetWarningOutput(w)
+}
+
+func SetErrorOutput(w io.Writer) error {
+	checkInit()
+	return global.SetErrorOutput(w)
+}
+
+func Run(ctx context.Context, args []string) error {
+	checkInit()
+	return global.Run(ctx, args)
+}
+
+func Exit(err error) {
+	checkInit()
+	global.Exit(err)
+}
+
+func SetEntryTemplate(tmpl *template.Template) error {
+	checkInit()
+	return global.SetEntryTemplate(tmpl)
 }
 
-func SetEntryTemplate(tmpl *template.Template) {
+func SetOptionTemplate(tmpl *template.Template) error {
 	checkInit()
-	visit(global, func(e *Entry) {
-		e.setTemplate(tmpl)
-	})
+	return global.SetOptionTemplate(tmpl)
 }
 
-func SetOptionTemplate(tmpl *template.Template) {
+func Seal() error {
 	checkInit()
-	visit(global, func(e *Entry) {
-		for i := range e.options {
-			e.options[i].setTemplate(tmpl)
-		}
-	})
+	return global.Seal()
 }
 
 func checkInit() {
-	if global == nil {
+	if global.checkInit() != nil {
 		panic(&UsageError{errors.New("global usage not initialized")})
 	}
 }
@@ -18,7 +18,7 @@ func (tester initTester) assertEntry() func(*testing.T) {
 	return func(t *testing.T) {
 		gotErr := Init(tester.iName)
 		assertNilError(t, gotErr)
-		assertDefaultEntry(t, global, stringToEntry(tester.iName))
+		assertDefaultEntry(t, global.root, stringToEntry(tester.iName))
 		global = nil
 	}
 }
@@ -27,7 +27,7 @@ func (tester initTester) assertEmptyNameStringError() func(*testing.T) {
 	return func(t *testing.T) {
 		got := Init(tester.iName)
 		assertEmptyNameStringError(t, got, tester.oErr)
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -38,7 +38,7 @@ type argsTester struct {
 
 func (tester argsTester) assertArgs() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{args: tester.oArgs}
+		global = &Program{root: &Entry{args: tester.oArgs}}
 		got := Args()
 		assertArgs(t, got, tester.oArgs)
 		global = nil
@@ -49,7 +49,7 @@ func (tester argsTester) assertUninitializedErrorPanic() func(*testing.T) {
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		Args()
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -60,7 +60,7 @@ type optionsTester struct {
 
 func (tester optionsTester) assertOptions() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{options: tester.oOptions}
+		global = &Program{root: &Entry{options: tester.oOptions}}
 		got := Options()
 		assertOptions(t, got, tester.oOptions)
 		global = nil
@@ -71,7 +71,7 @@ func (tester optionsTester) assertUninitializedErrorPanic() func(*testing.T) {
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		Options()
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -85,9 +85,9 @@ func (tester entriesTester) assertEntries() func(*testing.T) {
 		sort.Slice(tester.oEntries, func(i, j int) bool {
 			return tester.oEntries[i].name < tester.oEntries[j].name
 		})
-		global = &Entry{children: make(map[string]*Entry)}
+		global = &Program{root: &Entry{children: make(map[string]*Entry)}}
 		for i, e := range tester.oEntries {
-			global.children[e.name] = &tester.oEntries[i]
+			global.root.children[e.name] = &tester.oEntries[i]
 		}
 		got := Entries()
 		assertEntries(t, got, tester.oEntries)
@@ -99,7 +99,7 @@ func (tester entriesTester) assertUninitializedErrorPanic() func(*testing.T) {
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		Entries()
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -113,20 +113,20 @@ func (tester addArgTester) assertArgs() func(*testing.T) {
 	return func(t *testing.T) {
 		iterations := 3
 		args := make([]string, 0, iterations)
-		global = &Entry{args: make([]string, 0)}
+		global = &Program{root: &Entry{args: make([]string, 0)}}
 		for i := 1; i <= iterations; i++ {
 			gotErr := AddArg(tester.iArg)
 			assertNilError(t, gotErr)
 			args = append(args, tester.iArg)
 		}
-		assertArgs(t, global.args, args)
+		assertArgs(t, global.root.args, args)
 		global = nil
 	}
 }
 
 func (tester addArgTester) assertEmptyArgStringError() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{args: make([]string, 0)}
+		global = &Program{root: &Entry{args: make([]string, 0)}}
 		got := AddArg(tester.iArg)
 		assertEmptyArgStringError(t, got, tester.oErr)
 		global = nil
@@ -135,12 +135,12 @@ func (tester addArgTester) assertEmptyArgStringError() func(*testing.T) {
 
 func (tester addArgTester) assertExistingEntriesError() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{
+		global = &Program{root: &Entry{
 			children: map[string]*Entry{
 				"foo": {name: "foo"},
 			},
 			args: make([]string, 0),
-		}
+		}}
 		got := AddArg(tester.iArg)
 		assertExistingEntriesError(t, got, tester.oErr)
 		global = nil
@@ -151,7 +151,7 @@ func (tester addArgTester) assertUninitializedErrorPanic() func(*testing.T) {
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		AddArg(tester.iArg)
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -165,20 +165,20 @@ func (tester addOptionTester) assertOptions() func(*testing.T) {
 	return func(t *testing.T) {
 		iterations := 3
 		options := make([]Option, 0, iterations)
-		global = &Entry{options: make([]Option, 0)}
+		global = &Program{root: &Entry{options: make([]Option, 0)}}
 		for i := 1; i <= iterations; i++ {
 			gotErr := AddOption(tester.iOption)
 			assertNilError(t, gotErr)
 			options = append(options, *tester.iOption)
 		}
-		assertOptions(t, global.options, options)
+		assertOptions(t, global.root.options, options)
 		global = nil
 	}
 }
 
 func (tester addOptionTester) assertNoOptionError() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{options: make([]Option, 0)}
+		global = &Program{root: &Entry{options: make([]Option, 0)}}
 		got := AddOption(tester.iOption)
 		assertNoOptionError(t, got, tester.oErr)
 		global = nil
@@ -187,7 +187,7 @@ func (tester addOptionTester) assertNoOptionError() func(*testing.T) {
 
 func (tester addOptionTester) assertNoAliasesError() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{options: make([]Option, 0)}
+		global = &Program{root: &Entry{options: make([]Option, 0)}}
 		got := AddOption(tester.iOption)
 		assertNoAliasesError(t, got, tester.oErr)
 		global = nil
@@ -196,7 +196,7 @@ func (tester addOptionTester) assertNoAliasesError() func(*testing.T) {
 
 func (tester addOptionTester) assertEmptyAliasStringError() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{options: make([]Option, 0)}
+		global = &Program{root: &Entry{options: make([]Option, 0)}}
 		got := AddOption(tester.iOption)
 		assertEmptyAliasStringError(t, got, tester.oErr)
 		global = nil
@@ -207,7 +207,7 @@ func (tester addOptionTester) assertUninitializedErrorPanic() func(*testing.T) {
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		AddOption(tester.iOption)
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -221,23 +221,23 @@ func (tester addEntryTester) assertChildren() func(*testing.T) {
 	return func(t *testing.T) {
 		iterations := 3
 		entries := make(map[string]*Entry)
-		global = &Entry{children: make(map[string]*Entry)}
+		global = &Program{root: &Entry{children: make(map[string]*Entry)}}
 		for i := 1; i <= iterations; i++ {
 			child := *tester.iEntry
 			child.name += fmt.Sprintf("-%d", i)
 			gotErr := AddEntry(&child)
 			assertNilError(t, gotErr)
-			assertParent(t, child.parent, global)
+			assertParent(t, child.parent, global.root)
 			entries[child.name] = &child
 		}
-		assertChildren(t, global.children, entries)
+		assertChildren(t, global.root.children, entries)
 		global = nil
 	}
 }
 
 func (tester addEntryTester) assertNoEntryError() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{children: make(map[string]*Entry)}
+		global = &Program{root: &Entry{children: make(map[string]*Entry)}}
 		got := AddEntry(tester.iEntry)
 		assertNoEntryError(t, got, tester.oErr)
 		global = nil
@@ -246,7 +246,7 @@ func (tester addEntryTester) assertNoEntryError() func(*testing.T) {
 
 func (tester addEntryTester) assertEmptyNameStringError() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{children: make(map[string]*Entry)}
+		global = &Program{root: &Entry{children: make(map[string]*Entry)}}
 		got := AddEntry(tester.iEntry)
 		assertEmptyNameStringError(t, got, tester.oErr)
 		global = nil
@@ -255,10 +255,10 @@ func (tester addEntryTester) assertEmptyNameStringError() func(*testing.T) {
 
 func (tester addEntryTester) assertExistingArgsError() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{
+		global = &Program{root: &Entry{
 			children: make(map[string]*Entry),
 			args:     []string{"foo"},
-		}
+		}}
 		got := AddEntry(tester.iEntry)
 		assertExistingArgsError(t, got, tester.oErr)
 		global = nil
@@ -269,7 +269,7 @@ func (tester addEntryTester) assertUninitializedErrorPanic() func(*testing.T) {
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		AddEntry(tester.iEntry)
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -281,17 +281,17 @@ type setNameTester struct {
 
 func (tester setNameTester) assertName() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{name: tester.iName}
+		global = &Program{root: &Entry{name: tester.iName}}
 		gotErr := SetName(tester.iName)
 		assertNilError(t, gotErr)
-		assertName(t, global.name, tester.iName)
+		assertName(t, global.root.name, tester.iName)
 		global = nil
 	}
 }
 
 func (tester setNameTester) assertEmptyNameStringError() func(*testing.T) {
 	return func(t *testing.T) {
-		global = &Entry{name: "foo"}
+		global = &Program{root: &Entry{name: "foo"}}
 		got := SetName(tester.iName)
 		assertEmptyNameStringError(t, got, tester.oErr)
 		global = nil
@@ -302,7 +302,7 @@ func (tester setNameTester) assertUninitializedErrorPanic() func(*testing.T) {
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		SetName(tester.iName)
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -313,7 +313,7 @@ type usageTester struct {
 
 func (tester usageTester) assertUsage() func(*testing.T) {
 	return func(t *testing.T) {
-		global = stringToEntry(tester.oUsage)
+		global = &Program{root: stringToEntry(tester.oUsage)}
 		got := Usage()
 		assertUsage(t, got, tester.oUsage)
 		global = nil
@@ -324,7 +324,31 @@ func (tester usageTester) assertUninitializedErrorPanic() func(*testing.T) {
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		Usage()
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
+	}
+}
+
+type usageAllTester struct {
+	oUsage string
+	oPanic error
+}
+
+func (tester usageAllTester) assertUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		global.root.tmpl = template.Must(template.New("").Parse("{{range .Entries}}{{.Name}} {{end}}"))
+		global.root.children["db"].hidden = true
+		got := UsageAll()
+		assertUsage(t, got, tester.oUsage)
+		global = nil
+	}
+}
+
+func (tester usageAllTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		UsageAll()
+		assertNilProgram(t, global)
 	}
 }
 
@@ -345,12 +369,12 @@ func (tester lookupTester) assertUsage() func(*testing.T) {
 			"chop":    chopEssay,
 		}
 		iterations := 3
-		global = &Entry{
+		global = &Program{root: &Entry{
 			name:     "base",
 			children: make(map[string]*Entry),
 			tmpl:     template.Must(template.New("").Funcs(fn).Parse(rawTmpl)),
-		}
-		ptr := global
+		}}
+		ptr := global.root
 		for i := 1; i <= iterations; i++ {
 			entry := Entry{
 				name:     fmt.Sprintf("level-%d", i),
@@ -371,7 +395,7 @@ func (tester lookupTester) assertUninitializedErrorPanic() func(*testing.T) {
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		Lookup(tester.iLookup)
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -383,8 +407,8 @@ type setEntryTemplateTester struct {
 func (tester setEntryTemplateTester) assertTemplate() func(*testing.T) {
 	return func(t *testing.T) {
 		iterations := 3
-		global = &Entry{name: "base", children: make(map[string]*Entry)}
-		ptr := global
+		global = &Program{root: &Entry{name: "base", children: make(map[string]*Entry)}}
+		ptr := global.root
 		for i := 1; i <= iterations; i++ {
 			entry := Entry{
 				name:     fmt.Sprintf("level-%d", i),
@@ -395,7 +419,7 @@ func (tester setEntryTemplateTester) assertTemplate() func(*testing.T) {
 			ptr = &entry
 		}
 		SetEntryTemplate(tester.iTemplate)
-		visit(global, func(e *Entry) {
+		visit(global.root, func(e *Entry) {
 			assertTemplate(t, e.tmpl, tester.iTemplate)
 		})
 		global = nil
@@ -406,7 +430,7 @@ func (tester setEntryTemplateTester) assertUninitializedErrorPanic() func(*testi
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		SetEntryTemplate(tester.iTemplate)
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
 	}
 }
 
@@ -418,8 +442,8 @@ type setOptionTemplateTester struct {
 func (tester setOptionTemplateTester) assertTemplate() func(*testing.T) {
 	return func(t *testing.T) {
 		iterations := 3
-		global = &Entry{name: "base", children: make(map[string]*Entry)}
-		ptr := global
+		global = &Program{root: &Entry{name: "base", children: make(map[string]*Entry)}}
+		ptr := global.root
 		for i := 1; i <= iterations; i++ {
 			entry := Entry{
 				name:     fmt.Sprintf("level-%d", i),
@@ -431,7 +455,7 @@ func (tester setOptionTemplateTester) assertTemplate() func(*testing.T) {
 			ptr = &entry
 		}
 		SetOptionTemplate(tester.iTemplate)
-		visit(global, func(e *Entry) {
+		visit(global.root, func(e *Entry) {
 			for _, option := range e.options {
 				assertTemplate(t, option.tmpl, tester.iTemplate)
 			}
@@ -444,7 +468,91 @@ func (tester setOptionTemplateTester) assertUninitializedErrorPanic() func(*test
 	return func(t *testing.T) {
 		defer assertUninitializedPanic(t, tester.oPanic)
 		SetEntryTemplate(tester.iTemplate)
-		assertNilEntry(t, global)
+		assertNilProgram(t, global)
+	}
+}
+
+type lookupPathTester struct {
+	iPath  []string
+	oUsage string
+	oErr   error
+	oPanic error
+}
+
+func (tester lookupPathTester) assertUsage() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		got, gotErr := LookupPath(tester.iPath...)
+		assertNilError(t, gotErr)
+		assertUsage(t, got, tester.oUsage)
+		global = nil
+	}
+}
+
+func (tester lookupPathTester) assertNotFoundError() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		gotUsage, got := LookupPath(tester.iPath...)
+		assertUsage(t, gotUsage, "")
+		assertNotFoundError(t, got, tester.oErr)
+		global = nil
+	}
+}
+
+func (tester lookupPathTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		LookupPath(tester.iPath...)
+		assertNilProgram(t, global)
+	}
+}
+
+type findTester struct {
+	iPath  []string
+	oUsage string
+	oPanic error
+}
+
+func (tester findTester) assertEntry() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		got, gotErr := Find(tester.iPath...)
+		assertNilError(t, gotErr)
+		assertUsage(t, got.Usage(), tester.oUsage)
+		global = nil
+	}
+}
+
+func (tester findTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		Find(tester.iPath...)
+		assertNilProgram(t, global)
+	}
+}
+
+type sealTester struct {
+	oPanic error
+}
+
+func (tester sealTester) assertSealed() func(*testing.T) {
+	return func(t *testing.T) {
+		Init("base")
+		gotErr := Seal()
+		assertNilError(t, gotErr)
+		assertSealed(t, global.root.Sealed(), true)
+		option, _ := NewOption([]string{"--foo"}, "")
+		assertSealedError(t, AddOption(option), errors.New("usage: entry is sealed"))
+		assertSealedError(t, SetEntryTemplate(nil), errors.New("usage: entry is sealed"))
+		global = nil
+	}
+}
+
+func (tester sealTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		Seal()
+		assertNilProgram(t, global)
 	}
 }
 
@@ -576,6 +684,15 @@ func TestSetName(t *testing.T) {
 	}.assertUninitializedErrorPanic())
 }
 
+func TestUsageAll(t *testing.T) {
+	t.Run("baseline", usageAllTester{
+		oUsage: "db user ",
+	}.assertUsage())
+	t.Run("uninitialized", usageAllTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
+
 func TestUsage(t *testing.T) {
 	const (
 		indent      = "    "
@@ -718,3 +835,34 @@ func TestSetOptionTemplate(t *testing.T) {
 		oPanic: errors.New("usage: global usage not initialized"),
 	}.assertUninitializedErrorPanic())
 }
+
+func TestSeal(t *testing.T) {
+	t.Run("baseline", sealTester{}.assertSealed())
+	t.Run("uninitialized", sealTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
+
+func TestLookupPath(t *testing.T) {
+	t.Run("baseline", lookupPathTester{
+		iPath:  []string{"db", "create"},
+		oUsage: "base:db:create",
+	}.assertUsage())
+	t.Run("untracked entry", lookupPathTester{
+		iPath: []string{"db", "foo"},
+		oErr:  errors.New("usage: entry 'foo' not found in 'base db'"),
+	}.assertNotFoundError())
+	t.Run("uninitialized", lookupPathTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
+
+func TestFind(t *testing.T) {
+	t.Run("baseline", findTester{
+		iPath:  []string{"user", "create"},
+		oUsage: "base:user:create",
+	}.assertEntry())
+	t.Run("uninitialized", findTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}
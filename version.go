@@ -0,0 +1,128 @@
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+type VersionMode int
+
+const (
+	VersionOption VersionMode = 1 << iota
+	VersionCommand
+)
+
+type VersionInfo struct {
+	Version  string
+	Revision string
+	Time     string
+}
+
+func (v VersionInfo) String() string {
+	details := make([]string, 0, 2)
+	for _, detail := range []string{v.Revision, v.Time} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	if len(details) == 0 {
+		return v.Version
+	}
+	return fmt.Sprintf("%s (%s)", v.Version, strings.Join(details, ", "))
+}
+
+type versionSetting struct {
+	info   VersionInfo
+	option bool
+	entry  *Entry
+}
+
+var readBuildInfo = debug.ReadBuildInfo
+
+func (e *Entry) Version() string {
+	defer e.rlock()()
+	for ptr := e; ptr != nil; ptr = ptr.parent {
+		if ptr.version != nil {
+			return ptr.version.info.String()
+		}
+	}
+	return ""
+}
+
+func (e *Entry) EnableVersion(mode VersionMode, overrides VersionInfo) error {
+	defer e.lock()()
+	if e.Sealed() {
+		return &UsageError{errors.New("entry is sealed")}
+	}
+	if mode&^(VersionOption|VersionCommand) != 0 {
+		return &UsageError{fmt.Errorf("unknown version mode %d", mode)}
+	}
+	setting := &versionSetting{info: buildVersionInfo(overrides)}
+	if e.version != nil {
+		setting.option = e.version.option
+		setting.entry = e.version.entry
+	}
+	addOption := mode&VersionOption != 0 && !setting.option
+	addEntry := mode&VersionCommand != 0 && setting.entry == nil
+	if addOption && e.option("--version") != nil {
+		return &UsageError{fmt.Errorf("option alias '--version' already in use in '%s'", e.path())}
+	}
+	var entry *Entry
+	if addEntry {
+		if len(e.args) > 0 {
+			return &UsageError{errors.New("cannot add child entry with args present")}
+		}
+		entry, _ = NewEntry("version", "Show version information.")
+		if err := e.checkConflicts(entry, entry.names()); err != nil {
+			return err
+		}
+	}
+	if addOption {
+		option, _ := NewOption([]string{"--version"}, "Show version information.")
+		e.options = append(e.options, *option)
+		setting.option = true
+	}
+	if addEntry {
+		e.addChild(entry)
+		setting.entry = entry
+	}
+	e.version = setting
+	return nil
+}
+
+func (e *Entry) isVersionAlias(alias string) bool {
+	return e.version != nil && e.version.option && alias == "--version"
+}
+
+func (e *Entry) isVersionEntry() bool {
+	return e.parent != nil && e.parent.version != nil && e.parent.version.entry == e
+}
+
+func buildVersionInfo(overrides VersionInfo) VersionInfo {
+	info := VersionInfo{Version: "(devel)"}
+	if build, ok := readBuildInfo(); ok {
+		if build.Main.Version != "" {
+			info.Version = build.Main.Version
+		}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				info.Revision = setting.Value
+			case "vcs.time":
+				info.Time = setting.Value
+			}
+		}
+	}
+	if overrides.Version != "" {
+		info.Version = overrides.Version
+	}
+	if overrides.Revision != "" {
+		info.Revision = overrides.Revision
+	}
+	if overrides.Time != "" {
+		info.Time = overrides.Time
+	}
+	return info
+}
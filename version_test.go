@@ -0,0 +1,282 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func stubBuildInfo(build *debug.BuildInfo) func() {
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return build, build != nil
+	}
+	return func() {
+		readBuildInfo = debug.ReadBuildInfo
+	}
+}
+
+type versionInfoStringTester struct {
+	iInfo    VersionInfo
+	oVersion string
+}
+
+func (tester versionInfoStringTester) assertString() func(*testing.T) {
+	return func(t *testing.T) {
+		got := tester.iInfo.String()
+		assertVersion(t, got, tester.oVersion)
+	}
+}
+
+type buildVersionInfoTester struct {
+	iBuild     *debug.BuildInfo
+	iOverrides VersionInfo
+	oInfo      VersionInfo
+}
+
+func (tester buildVersionInfoTester) assertInfo() func(*testing.T) {
+	return func(t *testing.T) {
+		defer stubBuildInfo(tester.iBuild)()
+		got := buildVersionInfo(tester.iOverrides)
+		if got != tester.oInfo {
+			t.Errorf("version info is %+v but should be %+v", got, tester.oInfo)
+		}
+	}
+}
+
+type entryEnableVersionTester struct {
+	iMode    VersionMode
+	oOption  bool
+	oCommand bool
+	oVersion string
+	oErr     error
+}
+
+func (tester entryEnableVersionTester) assertVersion() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		gotErr := sampleEntry.EnableVersion(tester.iMode, VersionInfo{Version: "v1.2.3", Revision: "abc123"})
+		assertNilError(t, gotErr)
+		if gotOption := sampleEntry.option("--version") != nil; gotOption != tester.oOption {
+			t.Errorf("version option added is %t but should be %t", gotOption, tester.oOption)
+		}
+		if _, gotCommand := sampleEntry.children["version"]; gotCommand != tester.oCommand {
+			t.Errorf("version command added is %t but should be %t", gotCommand, tester.oCommand)
+		}
+		child, _ := sampleEntry.Find("db", "create")
+		assertVersion(t, child.Version(), tester.oVersion)
+	}
+}
+
+func (tester entryEnableVersionTester) assertArgsAdded() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry, _ := NewEntry("base", "")
+		assertNilError(t, sampleEntry.EnableHelp(nil))
+		assertNilError(t, sampleEntry.EnableVersion(tester.iMode, VersionInfo{Version: "v1.2.3", Revision: "abc123"}))
+		assertNilError(t, sampleEntry.AddArg("<foo>"))
+		assertEntries(t, sampleEntry.Entries(), []Entry{})
+		result, _ := sampleEntry.Parse([]string{"--version"})
+		if result.Version != tester.oOption {
+			t.Errorf("version option recognized is %t but should be %t", result.Version, tester.oOption)
+		}
+		foo, _ := NewEntry("foo", "")
+		got := sampleEntry.AddEntry(foo)
+		assertExistingArgsError(t, got, tester.oErr)
+	}
+}
+
+func (tester entryEnableVersionTester) assertOptionConflictError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.AddOption(&Option{aliases: []string{"--version"}})
+		got := sampleEntry.EnableVersion(tester.iMode, VersionInfo{})
+		assertConflictError(t, got, tester.oErr)
+		assertVersion(t, sampleEntry.Version(), "")
+	}
+}
+
+func (tester entryEnableVersionTester) assertCommandConflictError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.children["user"].SetAliases([]string{"version"})
+		got := sampleEntry.EnableVersion(tester.iMode, VersionInfo{})
+		assertConflictError(t, got, tester.oErr)
+		assertNilOption(t, sampleEntry.option("--version"))
+	}
+}
+
+func (tester entryEnableVersionTester) assertUnknownModeError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		got := sampleEntry.EnableVersion(tester.iMode, VersionInfo{})
+		assertError(t, got, tester.oErr)
+	}
+}
+
+func (tester entryEnableVersionTester) assertSealedError() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := &Entry{name: "foo", children: make(map[string]*Entry), tree: newSealedTree()}
+		got := sampleEntry.EnableVersion(tester.iMode, VersionInfo{})
+		assertSealedError(t, got, tester.oErr)
+		assertVersion(t, sampleEntry.Version(), "")
+	}
+}
+
+type entryParseVersionTester struct {
+	iArgs    []string
+	oVersion bool
+}
+
+func (tester entryParseVersionTester) assertVersion() func(*testing.T) {
+	return func(t *testing.T) {
+		sampleEntry := newPathTree()
+		sampleEntry.EnableVersion(VersionOption|VersionCommand, VersionInfo{Version: "v1.2.3"})
+		got, gotErr := sampleEntry.Parse(tester.iArgs)
+		assertNilError(t, gotErr)
+		if got.Version != tester.oVersion {
+			t.Errorf("version is %t but should be %t", got.Version, tester.oVersion)
+		}
+	}
+}
+
+type enableVersionTester struct {
+	iArgs    []string
+	oVersion string
+	oPanic   error
+}
+
+func (tester enableVersionTester) assertVersion() func(*testing.T) {
+	return func(t *testing.T) {
+		global = &Program{root: newPathTree()}
+		var b strings.Builder
+		SetOutput(&b)
+		gotErr := EnableVersion(VersionOption|VersionCommand, VersionInfo{Version: "v1.2.3", Time: "2024-01-02T03:04:05Z"})
+		assertNilError(t, gotErr)
+		assertNilError(t, Run(context.Background(), tester.iArgs))
+		assertUsage(t, b.String(), tester.oVersion)
+		global = nil
+	}
+}
+
+func (tester enableVersionTester) assertUninitializedErrorPanic() func(*testing.T) {
+	return func(t *testing.T) {
+		defer assertUninitializedPanic(t, tester.oPanic)
+		EnableVersion(VersionOption, VersionInfo{})
+		assertNilProgram(t, global)
+	}
+}
+
+func TestVersionInfoString(t *testing.T) {
+	t.Run("baseline", versionInfoStringTester{
+		iInfo:    VersionInfo{Version: "v1.2.3", Revision: "abc123", Time: "2024-01-02T03:04:05Z"},
+		oVersion: "v1.2.3 (abc123, 2024-01-02T03:04:05Z)",
+	}.assertString())
+	t.Run("version only", versionInfoStringTester{
+		iInfo:    VersionInfo{Version: "v1.2.3"},
+		oVersion: "v1.2.3",
+	}.assertString())
+	t.Run("no time", versionInfoStringTester{
+		iInfo:    VersionInfo{Version: "v1.2.3", Revision: "abc123"},
+		oVersion: "v1.2.3 (abc123)",
+	}.assertString())
+}
+
+func TestBuildVersionInfo(t *testing.T) {
+	sampleBuild := &debug.BuildInfo{
+		Main: debug.Module{Version: "v1.2.3"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.time", Value: "2024-01-02T03:04:05Z"},
+		},
+	}
+	t.Run("baseline", buildVersionInfoTester{
+		iBuild: sampleBuild,
+		oInfo:  VersionInfo{Version: "v1.2.3", Revision: "abc123", Time: "2024-01-02T03:04:05Z"},
+	}.assertInfo())
+	t.Run("overrides", buildVersionInfoTester{
+		iBuild:     sampleBuild,
+		iOverrides: VersionInfo{Version: "v2.0.0", Revision: "def456"},
+		oInfo:      VersionInfo{Version: "v2.0.0", Revision: "def456", Time: "2024-01-02T03:04:05Z"},
+	}.assertInfo())
+	t.Run("no build info", buildVersionInfoTester{
+		oInfo: VersionInfo{Version: "(devel)"},
+	}.assertInfo())
+	t.Run("no build info with overrides", buildVersionInfoTester{
+		iOverrides: VersionInfo{Version: "v2.0.0"},
+		oInfo:      VersionInfo{Version: "v2.0.0"},
+	}.assertInfo())
+}
+
+func TestEntryEnableVersion(t *testing.T) {
+	defer stubBuildInfo(nil)()
+	t.Run("baseline", entryEnableVersionTester{
+		iMode:    VersionOption | VersionCommand,
+		oOption:  true,
+		oCommand: true,
+		oVersion: "v1.2.3 (abc123)",
+	}.assertVersion())
+	t.Run("option only", entryEnableVersionTester{
+		iMode:    VersionOption,
+		oOption:  true,
+		oVersion: "v1.2.3 (abc123)",
+	}.assertVersion())
+	t.Run("command only", entryEnableVersionTester{
+		iMode:    VersionCommand,
+		oCommand: true,
+		oVersion: "v1.2.3 (abc123)",
+	}.assertVersion())
+	t.Run("args added after", entryEnableVersionTester{
+		iMode:   VersionOption | VersionCommand,
+		oOption: true,
+		oErr:    errors.New("usage: cannot add child entry with args present"),
+	}.assertArgsAdded())
+	t.Run("info only", entryEnableVersionTester{
+		oVersion: "v1.2.3 (abc123)",
+	}.assertVersion())
+	t.Run("conflicting option", entryEnableVersionTester{
+		iMode: VersionOption,
+		oErr:  errors.New("usage: option alias '--version' already in use in 'base'"),
+	}.assertOptionConflictError())
+	t.Run("conflicting command", entryEnableVersionTester{
+		iMode: VersionOption | VersionCommand,
+		oErr:  errors.New("usage: entry name or alias 'version' already in use in 'base'"),
+	}.assertCommandConflictError())
+	t.Run("unknown mode", entryEnableVersionTester{
+		iMode: 4,
+		oErr:  errors.New("usage: unknown version mode 4"),
+	}.assertUnknownModeError())
+	t.Run("sealed", entryEnableVersionTester{
+		oErr: errors.New("usage: entry is sealed"),
+	}.assertSealedError())
+}
+
+func TestEntryParseVersion(t *testing.T) {
+	t.Run("baseline", entryParseVersionTester{
+		iArgs:    []string{"--version"},
+		oVersion: true,
+	}.assertVersion())
+	t.Run("version command", entryParseVersionTester{
+		iArgs:    []string{"version"},
+		oVersion: true,
+	}.assertVersion())
+	t.Run("no version", entryParseVersionTester{
+		iArgs: []string{"db", "create", "foo"},
+	}.assertVersion())
+}
+
+func TestEnableVersion(t *testing.T) {
+	defer stubBuildInfo(nil)()
+	t.Run("baseline", enableVersionTester{
+		iArgs:    []string{"--version"},
+		oVersion: "v1.2.3 (2024-01-02T03:04:05Z)\n",
+	}.assertVersion())
+	t.Run("version command", enableVersionTester{
+		iArgs:    []string{"version"},
+		oVersion: "v1.2.3 (2024-01-02T03:04:05Z)\n",
+	}.assertVersion())
+	t.Run("uninitialized", enableVersionTester{
+		oPanic: errors.New("usage: global usage not initialized"),
+	}.assertUninitializedErrorPanic())
+}